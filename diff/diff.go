@@ -0,0 +1,348 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+)
+
+// The direction of the data that a schema describes, which decides whether a change of the schema is breaking,
+// e.g. a new required property breaks the clients that send a request, but not the clients that receive a response.
+type Direction string
+
+const (
+	DirectionRequest  Direction = "request"
+	DirectionResponse Direction = "response"
+)
+
+// A single change between the old specifications and the new specifications.
+type Change struct {
+	// Whether the change breaks the clients of the old specifications
+	Breaking bool `yaml:"breaking" json:"breaking"`
+
+	// The location of the change in the specifications, e.g. `paths."/users".get.parameters.query.limit`
+	Location string `yaml:"location" json:"location"`
+
+	// The direction of the schema that changed, empty for the changes that aren't changes of schemas
+	Direction Direction `yaml:"direction,omitempty" json:"direction,omitempty"`
+
+	// A human readable description of the change
+	Message string `yaml:"message" json:"message"`
+}
+
+func (c Change) String() string {
+	severity := "non-breaking"
+	if c.Breaking {
+		severity = "breaking"
+	}
+
+	if c.Direction != "" {
+		return fmt.Sprintf("[%s] %s (%s): %s", severity, c.Location, c.Direction, c.Message)
+	}
+
+	return fmt.Sprintf("[%s] %s: %s", severity, c.Location, c.Message)
+}
+
+// Compare the old specifications with the new specifications and classify every change as breaking or non-breaking.
+func Compare(old *echo_swagger.OpenAPI, new *echo_swagger.OpenAPI) Report {
+	c := &comparator{
+		old:     old,
+		new:     new,
+		visited: map[string]bool{},
+		changes: []Change{},
+	}
+
+	c.comparePaths()
+
+	return Report{
+		OldVersion: old.Info.Version,
+		NewVersion: new.Info.Version,
+		Changes:    c.changes,
+	}
+}
+
+type comparator struct {
+	old *echo_swagger.OpenAPI
+	new *echo_swagger.OpenAPI
+
+	// The component schemas that were already compared (by their references and direction), so every change
+	// of a component is reported once and recursive components are compared once
+	visited map[string]bool
+
+	changes []Change
+}
+
+func (c *comparator) report(breaking bool, path []string, direction Direction, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Breaking:  breaking,
+		Location:  echo_swagger.FormatLocation(path),
+		Direction: direction,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *comparator) comparePaths() {
+	for _, route := range unionKeys(c.old.Paths, c.new.Paths) {
+		oldPath, newPath := c.old.Paths[route], c.new.Paths[route]
+		if oldPath == nil {
+			oldPath = &echo_swagger.Path{}
+		}
+
+		if newPath == nil {
+			newPath = &echo_swagger.Path{}
+		}
+
+		for _, method := range echo_swagger.Methods {
+			oldOperation, _ := oldPath.GetOperationByMethod(method)
+			newOperation, _ := newPath.GetOperationByMethod(method)
+			path := []string{"paths", route, strings.ToLower(method)}
+
+			switch {
+			case oldOperation == nil && newOperation == nil:
+				continue
+
+			case oldOperation == nil:
+				c.report(false, path, "", "operation `%s %s` was added", method, route)
+
+			case newOperation == nil:
+				c.report(true, path, "", "operation `%s %s` was removed", method, route)
+
+			default:
+				c.compareOperation(path, oldPath, oldOperation, newPath, newOperation)
+			}
+		}
+	}
+}
+
+func (c *comparator) compareOperation(path []string, oldPath *echo_swagger.Path, oldOperation *echo_swagger.Operation, newPath *echo_swagger.Path, newOperation *echo_swagger.Operation) {
+	if !oldOperation.Deprecated && newOperation.Deprecated {
+		c.report(false, path, "", "operation was deprecated")
+	}
+
+	c.compareSecurity(at(path, "security"), operationSecurity(c.old, oldOperation), operationSecurity(c.new, newOperation))
+	c.compareParameters(at(path, "parameters"), operationParameters(oldPath, oldOperation), operationParameters(newPath, newOperation))
+	c.compareRequestBody(at(path, "requestBody"), oldOperation.RequestBody, newOperation.RequestBody)
+
+	for _, code := range unionKeys(oldOperation.Responses, newOperation.Responses) {
+		oldResponse, inOld := oldOperation.Responses[code]
+		newResponse, inNew := newOperation.Responses[code]
+		responsePath := at(path, "responses", code)
+
+		switch {
+		case !inOld:
+			c.report(false, responsePath, "", "response `%s` was added", code)
+
+		case !inNew:
+			c.report(true, responsePath, "", "response `%s` was removed", code)
+
+		default:
+			oldResponse, newResponse = resolveResponse(c.old, oldResponse), resolveResponse(c.new, newResponse)
+			c.compareHeaders(at(responsePath, "headers"), oldResponse.Headers, newResponse.Headers)
+			c.compareContent(at(responsePath, "content"), DirectionResponse, oldResponse.Content, newResponse.Content)
+		}
+	}
+}
+
+// Returns the component response that a response references, or the response itself whenever it isn't a reference.
+func resolveResponse(openapi *echo_swagger.OpenAPI, response echo_swagger.Response) echo_swagger.Response {
+	if response.Reference == "" {
+		return response
+	}
+
+	return openapi.Components.Responses[strings.TrimPrefix(response.Reference, echo_swagger.ComponentResponsesPrefix)]
+}
+
+// Returns the security requirements that apply to an operation, which are the requirements of the operation itself
+// or the requirements of the whole specifications whenever the operation doesn't declare its own.
+func operationSecurity(openapi *echo_swagger.OpenAPI, operation *echo_swagger.Operation) map[string]echo_swagger.SecurityRequirement {
+	requirements := openapi.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+
+	security := map[string]echo_swagger.SecurityRequirement{}
+	for _, requirement := range requirements {
+		security[formatRequirement(requirement)] = requirement
+	}
+
+	return security
+}
+
+// Format a security requirement by its schemes and their scopes, e.g. `ApiKey & OAuth2(read:users)`.
+func formatRequirement(requirement echo_swagger.SecurityRequirement) string {
+	schemes := make([]string, 0, len(requirement))
+	for name, scopes := range requirement {
+		if len(scopes) > 0 {
+			scopes = append([]string{}, scopes...)
+			sort.Strings(scopes)
+			name += "(" + strings.Join(scopes, ", ") + ")"
+		}
+
+		schemes = append(schemes, name)
+	}
+
+	sort.Strings(schemes)
+	return strings.Join(schemes, " & ")
+}
+
+// Compare the security requirements of an operation. Every requirement is an alternative way to authorize the requests,
+// so adding one breaks only the operations that didn't require any security, and removing one breaks the clients that use it
+// (unless the operation doesn't require any security anymore).
+func (c *comparator) compareSecurity(path []string, oldSecurity map[string]echo_swagger.SecurityRequirement, newSecurity map[string]echo_swagger.SecurityRequirement) {
+	for _, key := range unionKeys(oldSecurity, newSecurity) {
+		_, inOld := oldSecurity[key]
+		_, inNew := newSecurity[key]
+
+		switch {
+		case !inOld && len(oldSecurity) == 0:
+			c.report(true, path, "", "security requirement `%s` was added to an operation that didn't require security", key)
+
+		case !inOld:
+			c.report(false, path, "", "alternative security requirement `%s` was added", key)
+
+		case !inNew && len(newSecurity) == 0:
+			c.report(false, path, "", "security requirement `%s` was removed, the operation doesn't require security", key)
+
+		case !inNew:
+			c.report(true, path, "", "security requirement `%s` was removed", key)
+		}
+	}
+}
+
+// Compare the headers of a response like the properties of a response schema, the clients may rely on the headers
+// that were removed or became optional.
+func (c *comparator) compareHeaders(path []string, oldHeaders map[string]echo_swagger.Header, newHeaders map[string]echo_swagger.Header) {
+	for _, name := range unionKeys(oldHeaders, newHeaders) {
+		oldHeader, inOld := oldHeaders[name]
+		newHeader, inNew := newHeaders[name]
+		headerPath := at(path, name)
+
+		switch {
+		case !inOld:
+			c.report(false, headerPath, DirectionResponse, "header `%s` was added", name)
+
+		case !inNew:
+			c.report(true, headerPath, DirectionResponse, "header `%s` was removed", name)
+
+		default:
+			if oldHeader.Required && !newHeader.Required {
+				c.report(true, headerPath, DirectionResponse, "header `%s` became optional", name)
+			} else if !oldHeader.Required && newHeader.Required {
+				c.report(false, headerPath, DirectionResponse, "header `%s` became required", name)
+			}
+
+			c.compareSchemas(at(headerPath, "schema"), DirectionResponse, &oldHeader.Schema.Property, &newHeader.Schema.Property)
+		}
+	}
+}
+
+// Returns the parameters of an operation (including the parameters of its path) by their location and name,
+// the parameters of the operation override the parameters of the path.
+func operationParameters(path *echo_swagger.Path, operation *echo_swagger.Operation) map[string]echo_swagger.Parameter {
+	parameters := map[string]echo_swagger.Parameter{}
+
+	for _, parameter := range append(append([]echo_swagger.Parameter{}, path.Parameters...), operation.Parameters...) {
+		parameters[string(parameter.In)+"."+parameter.Name] = parameter
+	}
+
+	return parameters
+}
+
+func (c *comparator) compareParameters(path []string, oldParameters map[string]echo_swagger.Parameter, newParameters map[string]echo_swagger.Parameter) {
+	for _, key := range unionKeys(oldParameters, newParameters) {
+		oldParameter, inOld := oldParameters[key]
+		newParameter, inNew := newParameters[key]
+
+		switch {
+		case !inOld:
+			parameterPath := at(path, string(newParameter.In), newParameter.Name)
+			if newParameter.Required {
+				c.report(true, parameterPath, "", "required %s parameter `%s` was added", newParameter.In, newParameter.Name)
+			} else {
+				c.report(false, parameterPath, "", "optional %s parameter `%s` was added", newParameter.In, newParameter.Name)
+			}
+
+		case !inNew:
+			c.report(true, at(path, string(oldParameter.In), oldParameter.Name), "", "%s parameter `%s` was removed", oldParameter.In, oldParameter.Name)
+
+		default:
+			parameterPath := at(path, string(oldParameter.In), oldParameter.Name)
+			if !oldParameter.Required && newParameter.Required {
+				c.report(true, parameterPath, "", "%s parameter `%s` became required", oldParameter.In, oldParameter.Name)
+			} else if oldParameter.Required && !newParameter.Required {
+				c.report(false, parameterPath, "", "%s parameter `%s` became optional", oldParameter.In, oldParameter.Name)
+			}
+
+			c.compareSchemas(at(parameterPath, "schema"), DirectionRequest, &oldParameter.Schema.Property, &newParameter.Schema.Property)
+		}
+	}
+}
+
+func (c *comparator) compareRequestBody(path []string, oldBody echo_swagger.RequestBody, newBody echo_swagger.RequestBody) {
+	switch {
+	case len(oldBody.Content) == 0 && len(newBody.Content) == 0:
+		return
+
+	case len(oldBody.Content) == 0:
+		if newBody.Required {
+			c.report(true, path, "", "required request body was added")
+		} else {
+			c.report(false, path, "", "optional request body was added")
+		}
+
+		return
+
+	case len(newBody.Content) == 0:
+		c.report(false, path, "", "request body was removed")
+		return
+	}
+
+	if !oldBody.Required && newBody.Required {
+		c.report(true, path, "", "request body became required")
+	}
+
+	c.compareContent(at(path, "content"), DirectionRequest, oldBody.Content, newBody.Content)
+}
+
+func (c *comparator) compareContent(path []string, direction Direction, oldContent map[string]echo_swagger.MediaType, newContent map[string]echo_swagger.MediaType) {
+	for _, contentType := range unionKeys(oldContent, newContent) {
+		oldMedia, inOld := oldContent[contentType]
+		newMedia, inNew := newContent[contentType]
+		mediaPath := at(path, contentType)
+
+		switch {
+		case !inOld:
+			c.report(false, mediaPath, direction, "content type `%s` was added", contentType)
+
+		case !inNew:
+			c.report(true, mediaPath, direction, "content type `%s` was removed", contentType)
+
+		default:
+			c.compareSchemas(at(mediaPath, "schema"), direction, &oldMedia.Schema.Property, &newMedia.Schema.Property)
+		}
+	}
+}
+
+// Returns a copy of the path with the keys appended, so the paths of sibling values don't share their arrays.
+func at(path []string, keys ...string) []string {
+	return append(append([]string{}, path...), keys...)
+}
+
+// Returns the sorted keys of both of the maps, so the changes are reported in a deterministic order.
+func unionKeys[T any](old map[string]T, new map[string]T) []string {
+	keys := make([]string, 0, len(old)+len(new))
+	for key := range old {
+		keys = append(keys, key)
+	}
+
+	for key := range new {
+		if _, ok := old[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,350 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	"github.com/stretchr/testify/assert"
+)
+
+const baseDocument = `
+openapi: 3.0.0
+info:
+    title: Example
+    version: 1.0.0
+paths:
+    /users:
+        get:
+            parameters:
+                - name: limit
+                  in: query
+                  required: false
+                  schema:
+                      type: integer
+                      maximum: 100
+            responses:
+                "200":
+                    description: The users
+                    content:
+                        application/json:
+                            schema:
+                                type: array
+                                items:
+                                    $ref: '#/components/schemas/api.User'
+        post:
+            security:
+                - ApiKey: []
+            requestBody:
+                required: true
+                content:
+                    application/json:
+                        schema:
+                            $ref: '#/components/schemas/api.User'
+            responses:
+                "201":
+                    description: The user
+                    headers:
+                        Location:
+                            required: true
+                            schema:
+                                type: string
+                "400":
+                    description: Invalid user
+components:
+    schemas:
+        api.User:
+            type: object
+            required:
+                - name
+            properties:
+                name:
+                    type: string
+                role:
+                    type: string
+                    enum:
+                        - admin
+                        - member
+`
+
+func TestCompare(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCase struct {
+		name     string
+		replace  []string
+		expected []string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "identical",
+			expected: []string{},
+		},
+		{
+			name:    "removed operation",
+			replace: []string{"        post:\n", "        put:\n"},
+			expected: []string{
+				"[non-breaking] paths.\"/users\".put: operation `PUT /users` was added",
+				"[breaking] paths.\"/users\".post: operation `POST /users` was removed",
+			},
+		},
+		{
+			name:    "required parameter",
+			replace: []string{"required: false", "required: true"},
+			expected: []string{
+				"[breaking] paths.\"/users\".get.parameters.query.limit: query parameter `limit` became required",
+			},
+		},
+		{
+			name:    "added parameter",
+			replace: []string{"name: limit", "name: count"},
+			expected: []string{
+				"[non-breaking] paths.\"/users\".get.parameters.query.count: optional query parameter `count` was added",
+				"[breaking] paths.\"/users\".get.parameters.query.limit: query parameter `limit` was removed",
+			},
+		},
+		{
+			name:    "narrowed bound",
+			replace: []string{"maximum: 100", "maximum: 50"},
+			expected: []string{
+				"[breaking] paths.\"/users\".get.parameters.query.limit.schema (request): `maximum` changed from 100 to 50",
+			},
+		},
+		{
+			name:    "property type",
+			replace: []string{"name:\n                    type: string", "name:\n                    type: integer"},
+			expected: []string{
+				"[breaking] components.schemas.\"api.User\".properties.name (response): type changed from `string` to `integer`",
+				"[breaking] components.schemas.\"api.User\".properties.name (request): type changed from `string` to `integer`",
+			},
+		},
+		{
+			name:    "narrowed enum",
+			replace: []string{"- admin\n", ""},
+			expected: []string{
+				"[non-breaking] components.schemas.\"api.User\".properties.role (response): enum values were removed: `admin`",
+				"[breaking] components.schemas.\"api.User\".properties.role (request): enum values were removed: `admin`",
+			},
+		},
+		{
+			name:    "widened enum",
+			replace: []string{"- member\n", "- member\n                        - guest\n"},
+			expected: []string{
+				"[breaking] components.schemas.\"api.User\".properties.role (response): enum values were added: `guest`",
+				"[non-breaking] components.schemas.\"api.User\".properties.role (request): enum values were added: `guest`",
+			},
+		},
+		{
+			name:    "required property",
+			replace: []string{"                - name\n", "                - name\n                - role\n"},
+			expected: []string{
+				"[non-breaking] components.schemas.\"api.User\".properties.role (response): property `role` became required",
+				"[breaking] components.schemas.\"api.User\".properties.role (request): property `role` became required",
+			},
+		},
+		{
+			name:    "removed property",
+			replace: []string{"role:", "email:"},
+			expected: []string{
+				"[non-breaking] components.schemas.\"api.User\".properties.email (response): optional property `email` was added",
+				"[breaking] components.schemas.\"api.User\".properties.role (response): property `role` was removed",
+				"[non-breaking] components.schemas.\"api.User\".properties.email (request): optional property `email` was added",
+				"[non-breaking] components.schemas.\"api.User\".properties.role (request): property `role` was removed",
+			},
+		},
+		{
+			name:    "removed header",
+			replace: []string{"Location:", "ETag:"},
+			expected: []string{
+				"[non-breaking] paths.\"/users\".post.responses.\"201\".headers.ETag (response): header `ETag` was added",
+				"[breaking] paths.\"/users\".post.responses.\"201\".headers.Location (response): header `Location` was removed",
+			},
+		},
+		{
+			name:    "optional header",
+			replace: []string{"Location:\n                            required: true", "Location:\n                            required: false"},
+			expected: []string{
+				"[breaking] paths.\"/users\".post.responses.\"201\".headers.Location (response): header `Location` became optional",
+			},
+		},
+		{
+			name:    "added security",
+			replace: []string{"        get:\n", "        get:\n            security:\n                - ApiKey: []\n"},
+			expected: []string{
+				"[breaking] paths.\"/users\".get.security: security requirement `ApiKey` was added to an operation that didn't require security",
+			},
+		},
+		{
+			name:    "alternative security",
+			replace: []string{"- ApiKey: []\n", "- ApiKey: []\n                - OAuth2:\n                    - write\n                    - read\n"},
+			expected: []string{
+				"[non-breaking] paths.\"/users\".post.security: alternative security requirement `OAuth2(read, write)` was added",
+			},
+		},
+		{
+			name:    "replaced security",
+			replace: []string{"- ApiKey: []\n", "- ApiKey: []\n                  BasicAuth: []\n"},
+			expected: []string{
+				"[breaking] paths.\"/users\".post.security: security requirement `ApiKey` was removed",
+				"[non-breaking] paths.\"/users\".post.security: alternative security requirement `ApiKey & BasicAuth` was added",
+			},
+		},
+		{
+			name:    "removed security",
+			replace: []string{"            security:\n                - ApiKey: []\n", ""},
+			expected: []string{
+				"[non-breaking] paths.\"/users\".post.security: security requirement `ApiKey` was removed, the operation doesn't require security",
+			},
+		},
+		{
+			name:    "removed response",
+			replace: []string{"\"400\":", "\"422\":"},
+			expected: []string{
+				"[breaking] paths.\"/users\".post.responses.\"400\": response `400` was removed",
+				"[non-breaking] paths.\"/users\".post.responses.\"422\": response `422` was added",
+			},
+		},
+	}
+
+	old, err := Load([]byte(baseDocument))
+	if !assert.NoError(err) {
+		return
+	}
+
+	for _, testCase := range testCases {
+		document := baseDocument
+		for index := 0; index+1 < len(testCase.replace); index += 2 {
+			document = strings.Replace(document, testCase.replace[index], testCase.replace[index+1], 1)
+		}
+
+		new, err := Load([]byte(document))
+		if !assert.NoError(err, testCase.name) {
+			continue
+		}
+
+		actual := []string{}
+		for _, change := range Compare(old, new).Changes {
+			actual = append(actual, change.String())
+		}
+
+		assert.Equal(testCase.expected, actual, testCase.name)
+	}
+}
+
+func TestLoadOpenApi31(t *testing.T) {
+	assert := assert.New(t)
+
+	minimum := 1.0
+
+	openapi := echo_swagger.OpenAPI{
+		OpenAPI: echo_swagger.OpenApiVersion31,
+		Info:    echo_swagger.Info{Title: "Example", Version: "1.0.0"},
+		Paths: map[string]*echo_swagger.Path{
+			"/users": {
+				Get: &echo_swagger.Operation{
+					Responses: map[string]echo_swagger.Response{
+						"200": {
+							Description: "The user",
+							Content: map[string]echo_swagger.MediaType{
+								echo_swagger.ContentTypeJson: {
+									Schema: echo_swagger.Schema{Property: echo_swagger.Property{
+										Type: echo_swagger.PropertyType_Object,
+										Properties: map[string]echo_swagger.Property{
+											"age":     {Type: echo_swagger.PropertyType_Integer, Minimum: &minimum, ExclusiveMinimum: true, Nullable: true},
+											"role":    {Type: echo_swagger.PropertyType_String, Enum: []interface{}{"admin"}, Nullable: true, Example: "admin"},
+											"manager": {Reference: echo_swagger.ComponentSchemasPrefix + "api.User", Nullable: true},
+										},
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := openapi.Marshal(echo_swagger.FormatYaml)
+	if !assert.NoError(err) {
+		return
+	}
+
+	loaded, err := Load(data)
+	if !assert.NoError(err) {
+		return
+	}
+
+	properties := loaded.Paths["/users"].Get.Responses["200"].Content[echo_swagger.ContentTypeJson].Schema.Properties
+	assert.Equal(echo_swagger.Property{Type: echo_swagger.PropertyType_Integer, Minimum: &minimum, ExclusiveMinimum: true, Nullable: true}, properties["age"])
+	assert.Equal(echo_swagger.Property{Type: echo_swagger.PropertyType_String, Enum: []interface{}{"admin"}, Nullable: true, Example: "admin"}, properties["role"])
+	assert.Equal(echo_swagger.Property{Reference: echo_swagger.ComponentSchemasPrefix + "api.User", Nullable: true}, properties["manager"])
+
+	assert.Empty(Compare(&openapi, loaded).Changes)
+
+	_, err = Load([]byte(`swagger: "2.0"`))
+	assert.Equal(UnsupportedOpenApiVersionError{Version: ""}, err)
+}
+
+func TestVersionBumped(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		old    string
+		new    string
+		bumped bool
+	}{
+		{"1.2.0", "2.0.0", true},
+		{"v1.2.0", "v2.0.0", true},
+		{"1.2.0", "1.3.0", false},
+		{"1.2.0", "1.2.0", false},
+		{"0.2.0", "0.3.0", true},
+		{"0.2.0", "0.2.1", false},
+		{"2022-01", "2022-02", true},
+		{"2022-01", "2022-01", false},
+	}
+
+	for _, testCase := range testCases {
+		report := Report{OldVersion: testCase.old, NewVersion: testCase.new}
+		assert.Equal(testCase.bumped, report.VersionBumped(), testCase.old+" => "+testCase.new)
+	}
+}
+
+func TestReportMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	report := Report{
+		OldVersion: "1.0.0",
+		NewVersion: "1.1.0",
+		Changes: []Change{
+			{Breaking: true, Location: `paths."/users".post`, Message: "operation `POST /users` was removed"},
+			{Breaking: false, Location: `components.schemas."api.User".properties.email`, Direction: DirectionResponse, Message: "optional property `email` was added"},
+		},
+	}
+
+	assert.True(report.Blocked())
+
+	text, err := report.Marshal(ReportFormatText)
+	assert.NoError(err)
+	assert.Equal("2 changes (1 breaking) from version `1.0.0` to version `1.1.0`\n"+
+		"[breaking] paths.\"/users\".post: operation `POST /users` was removed\n"+
+		"[non-breaking] components.schemas.\"api.User\".properties.email (response): optional property `email` was added\n"+
+		"The breaking changes require a version bump of `1.0.0`\n", string(text))
+
+	data, err := report.Marshal(ReportFormatJson)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"oldVersion": "1.0.0",
+		"newVersion": "1.1.0",
+		"changes": [
+			{"breaking": true, "location": "paths.\"/users\".post", "message": "operation `+"`POST /users`"+` was removed"},
+			{"breaking": false, "location": "components.schemas.\"api.User\".properties.email", "direction": "response", "message": "optional property `+"`email`"+` was added"}
+		],
+		"breaking": 1,
+		"versionBumped": false,
+		"blocked": true
+	}`, string(data))
+
+	_, err = report.Marshal("xml")
+	assert.Equal(InvalidReportFormatError{Format: "xml"}, err)
+}
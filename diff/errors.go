@@ -0,0 +1,21 @@
+package diff
+
+import "fmt"
+
+// An error that returned whenever the specifications to compare aren't OpenAPI 3.0 or OpenAPI 3.1 specifications
+type UnsupportedOpenApiVersionError struct {
+	Version string
+}
+
+func (e UnsupportedOpenApiVersionError) Error() string {
+	return fmt.Sprintf("unsupported OpenAPI version `%s`, only OpenAPI 3.0 & OpenAPI 3.1 specifications can be compared", e.Version)
+}
+
+// An error that returned whenever a report is written in an unknown format
+type InvalidReportFormatError struct {
+	Format string
+}
+
+func (e InvalidReportFormatError) Error() string {
+	return fmt.Sprintf("invalid report format `%s`, expected `text` or `json`", e.Format)
+}
@@ -0,0 +1,41 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	"gopkg.in/yaml.v3"
+)
+
+// Load OpenAPI specifications from either a YAML or a JSON document. The schemas of OpenAPI 3.1 specifications
+// are converted back to the semantics of OpenAPI 3.0 (see echo_swagger.ConvertFromOpenApi31), so they can be described
+// by the OpenAPI model types.
+func Load(data []byte) (*echo_swagger.OpenAPI, error) {
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(data, document); err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		OpenAPI string `yaml:"openapi"`
+	}
+
+	if err := document.Decode(&header); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(header.OpenAPI, "3.1"):
+		echo_swagger.ConvertFromOpenApi31(document)
+
+	case !strings.HasPrefix(header.OpenAPI, "3.0"):
+		return nil, UnsupportedOpenApiVersionError{Version: header.OpenAPI}
+	}
+
+	openapi := &echo_swagger.OpenAPI{}
+	if err := document.Decode(openapi); err != nil {
+		return nil, err
+	}
+
+	return openapi, nil
+}
@@ -0,0 +1,109 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// The format that a report is written in.
+type ReportFormat string
+
+const (
+	ReportFormatText ReportFormat = "text"
+	ReportFormatJson ReportFormat = "json"
+)
+
+// The changes between the old specifications and the new specifications, see Compare.
+type Report struct {
+	// The `info.version` of the old specifications
+	OldVersion string `json:"oldVersion"`
+
+	// The `info.version` of the new specifications
+	NewVersion string `json:"newVersion"`
+
+	// The changes by the order of their locations
+	Changes []Change `json:"changes"`
+}
+
+// Returns the breaking changes of the report.
+func (r Report) BreakingChanges() []Change {
+	breaking := []Change{}
+	for _, change := range r.Changes {
+		if change.Breaking {
+			breaking = append(breaking, change)
+		}
+	}
+
+	return breaking
+}
+
+// Whether the version of the new specifications allows breaking changes: a major version bump for semantic
+// versions (or a minor version bump for `0.x` versions), and any other version for the versions that aren't semantic.
+func (r Report) VersionBumped() bool {
+	old, new := canonicalVersion(r.OldVersion), canonicalVersion(r.NewVersion)
+	if !semver.IsValid(old) || !semver.IsValid(new) {
+		return r.OldVersion != r.NewVersion
+	}
+
+	if semver.Major(old) == "v0" {
+		return semver.Compare(semver.MajorMinor(new), semver.MajorMinor(old)) > 0
+	}
+
+	return semver.Compare(semver.Major(new), semver.Major(old)) > 0
+}
+
+// Whether the report contains breaking changes that don't come with a version bump, see VersionBumped.
+func (r Report) Blocked() bool {
+	return len(r.BreakingChanges()) > 0 && !r.VersionBumped()
+}
+
+// The versions are compared as semantic versions, which may omit the `v` prefix.
+func canonicalVersion(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	return version
+}
+
+// Write the report in the given format, a text with a line for every change or a JSON document.
+func (r Report) Marshal(format ReportFormat) ([]byte, error) {
+	switch format {
+	case ReportFormatText:
+		return r.marshalText(), nil
+
+	case ReportFormatJson:
+		data, err := json.MarshalIndent(struct {
+			Report
+			Breaking      int  `json:"breaking"`
+			VersionBumped bool `json:"versionBumped"`
+			Blocked       bool `json:"blocked"`
+		}{r, len(r.BreakingChanges()), r.VersionBumped(), r.Blocked()}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return append(data, '\n'), nil
+	}
+
+	return nil, InvalidReportFormatError{Format: string(format)}
+}
+
+func (r Report) marshalText() []byte {
+	buffer := &bytes.Buffer{}
+
+	fmt.Fprintf(buffer, "%d changes (%d breaking) from version `%s` to version `%s`\n", len(r.Changes), len(r.BreakingChanges()), r.OldVersion, r.NewVersion)
+	for _, change := range r.Changes {
+		fmt.Fprintln(buffer, change)
+	}
+
+	if r.Blocked() {
+		fmt.Fprintf(buffer, "The breaking changes require a version bump of `%s`\n", r.OldVersion)
+	}
+
+	return buffer.Bytes()
+}
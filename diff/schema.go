@@ -0,0 +1,244 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	"gopkg.in/yaml.v3"
+)
+
+// Whether narrowing a schema (accepting less values) breaks its clients, which is the case for the data that the clients send.
+func narrowingBreaks(direction Direction) bool {
+	return direction == DirectionRequest
+}
+
+// Whether widening a schema (producing more values) breaks its clients, which is the case for the data that the clients receive.
+func wideningBreaks(direction Direction) bool {
+	return direction == DirectionResponse
+}
+
+// Compare the schemas recursively, the referenced component schemas are resolved and their changes are reported
+// under their components.
+func (c *comparator) compareSchemas(path []string, direction Direction, old *echo_swagger.Property, new *echo_swagger.Property) {
+	if old.Reference != "" && new.Reference != "" {
+		key := fmt.Sprintf("%s:%s:%s", direction, old.Reference, new.Reference)
+		if c.visited[key] {
+			return
+		}
+
+		c.visited[key] = true
+		path = []string{"components", "schemas", strings.TrimPrefix(new.Reference, echo_swagger.ComponentSchemasPrefix)}
+	}
+
+	old, new = resolveSchema(c.old, old), resolveSchema(c.new, new)
+
+	if old.Type != "" && new.Type != "" && old.Type != new.Type {
+		c.report(true, path, direction, "type changed from `%s` to `%s`", old.Type, new.Type)
+		return
+	}
+
+	if old.Format != new.Format {
+		c.report(true, path, direction, "format changed from `%s` to `%s`", old.Format, new.Format)
+	}
+
+	if !old.Nullable && new.Nullable {
+		c.report(wideningBreaks(direction), path, direction, "became nullable")
+	} else if old.Nullable && !new.Nullable {
+		c.report(narrowingBreaks(direction), path, direction, "is no longer nullable")
+	}
+
+	c.compareEnums(path, direction, old.Enum, new.Enum)
+	c.compareBounds(path, direction, old, new)
+	c.compareProperties(path, direction, old, new)
+
+	if oldItems, newItems := propertyOf(old.Items), propertyOf(new.Items); oldItems != nil && newItems != nil {
+		c.compareSchemas(at(path, "items"), direction, oldItems, newItems)
+	}
+
+	if oldValues, newValues := propertyOf(old.AdditionalProperties), propertyOf(new.AdditionalProperties); oldValues != nil && newValues != nil {
+		c.compareSchemas(at(path, "additionalProperties"), direction, oldValues, newValues)
+	}
+
+	// More alternatives widen a schema, while more schemas that must all match narrow it
+	c.compareSubschemas(at(path, "oneOf"), direction, old.OneOf, new.OneOf, true)
+	c.compareSubschemas(at(path, "anyOf"), direction, old.AnyOf, new.AnyOf, true)
+	c.compareSubschemas(at(path, "allOf"), direction, old.AllOf, new.AllOf, false)
+}
+
+func (c *comparator) compareEnums(path []string, direction Direction, old []interface{}, new []interface{}) {
+	switch {
+	case len(old) == 0 && len(new) == 0:
+		return
+
+	case len(old) == 0:
+		c.report(narrowingBreaks(direction), path, direction, "values were restricted to an enum")
+		return
+
+	case len(new) == 0:
+		c.report(wideningBreaks(direction), path, direction, "values are no longer restricted to an enum")
+		return
+	}
+
+	if removed := missingValues(old, new); len(removed) > 0 {
+		c.report(narrowingBreaks(direction), path, direction, "enum values were removed: %s", strings.Join(removed, ", "))
+	}
+
+	if added := missingValues(new, old); len(added) > 0 {
+		c.report(wideningBreaks(direction), path, direction, "enum values were added: %s", strings.Join(added, ", "))
+	}
+}
+
+// Returns the values that are missing from the other values, formatted as code.
+func missingValues(values []interface{}, other []interface{}) []string {
+	existing := map[string]bool{}
+	for _, value := range other {
+		existing[fmt.Sprint(value)] = true
+	}
+
+	missing := []string{}
+	for _, value := range values {
+		if !existing[fmt.Sprint(value)] {
+			missing = append(missing, fmt.Sprintf("`%v`", value))
+		}
+	}
+
+	return missing
+}
+
+func (c *comparator) compareBounds(path []string, direction Direction, old *echo_swagger.Property, new *echo_swagger.Property) {
+	compare := func(name string, old *float64, new *float64, lower bool) {
+		switch {
+		case old == nil && new == nil:
+			return
+
+		case old == nil:
+			c.report(narrowingBreaks(direction), path, direction, "`%s` of %v was added", name, *new)
+
+		case new == nil:
+			c.report(wideningBreaks(direction), path, direction, "`%s` of %v was removed", name, *old)
+
+		case *old != *new:
+			// A lower bound narrows the schema when it's raised, an upper bound when it's lowered
+			narrowed := (*new > *old) == lower
+			breaking := (narrowed && narrowingBreaks(direction)) || (!narrowed && wideningBreaks(direction))
+			c.report(breaking, path, direction, "`%s` changed from %v to %v", name, *old, *new)
+		}
+	}
+
+	compare("minimum", old.Minimum, new.Minimum, true)
+	compare("maximum", old.Maximum, new.Maximum, false)
+	compare("minLength", float(old.MinLength), float(new.MinLength), true)
+	compare("maxLength", float(old.MaxLength), float(new.MaxLength), false)
+	compare("minItems", float(old.MinItems), float(new.MinItems), true)
+	compare("maxItems", float(old.MaxItems), float(new.MaxItems), false)
+	compare("minProperties", float(old.MinProperties), float(new.MinProperties), true)
+	compare("maxProperties", float(old.MaxProperties), float(new.MaxProperties), false)
+}
+
+func float(value *uint64) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	converted := float64(*value)
+	return &converted
+}
+
+func (c *comparator) compareProperties(path []string, direction Direction, old *echo_swagger.Property, new *echo_swagger.Property) {
+	oldRequired, newRequired := requiredSet(old), requiredSet(new)
+
+	for _, name := range unionKeys(old.Properties, new.Properties) {
+		oldProperty, inOld := old.Properties[name]
+		newProperty, inNew := new.Properties[name]
+		propertyPath := at(path, "properties", name)
+
+		switch {
+		case !inOld:
+			if newRequired[name] {
+				c.report(narrowingBreaks(direction), propertyPath, direction, "required property `%s` was added", name)
+			} else {
+				c.report(false, propertyPath, direction, "optional property `%s` was added", name)
+			}
+
+		case !inNew:
+			c.report(wideningBreaks(direction), propertyPath, direction, "property `%s` was removed", name)
+
+		default:
+			if !oldRequired[name] && newRequired[name] {
+				c.report(narrowingBreaks(direction), propertyPath, direction, "property `%s` became required", name)
+			} else if oldRequired[name] && !newRequired[name] {
+				c.report(wideningBreaks(direction), propertyPath, direction, "property `%s` became optional", name)
+			}
+
+			c.compareSchemas(propertyPath, direction, &oldProperty, &newProperty)
+		}
+	}
+}
+
+func requiredSet(property *echo_swagger.Property) map[string]bool {
+	required := map[string]bool{}
+	for _, name := range property.RequiredProperties {
+		required[name] = true
+	}
+
+	return required
+}
+
+// Compare the subschemas by their order, alternatives (`oneOf` & `anyOf`) widen the schema when they are added,
+// while the subschemas of `allOf` narrow it.
+func (c *comparator) compareSubschemas(path []string, direction Direction, old []echo_swagger.Property, new []echo_swagger.Property, alternatives bool) {
+	for index := 0; index < len(old) && index < len(new); index++ {
+		c.compareSchemas(at(path, fmt.Sprintf("[%d]", index)), direction, &old[index], &new[index])
+	}
+
+	// Adding alternatives widens the schema, and removing them narrows it
+	if len(new) > len(old) {
+		breaking := (alternatives && wideningBreaks(direction)) || (!alternatives && narrowingBreaks(direction))
+		c.report(breaking, path, direction, "%d subschemas were added", len(new)-len(old))
+	} else if len(old) > len(new) {
+		breaking := (alternatives && narrowingBreaks(direction)) || (!alternatives && wideningBreaks(direction))
+		c.report(breaking, path, direction, "%d subschemas were removed", len(old)-len(new))
+	}
+}
+
+// Returns the component schema that a schema references, or the schema itself whenever it isn't a reference.
+func resolveSchema(openapi *echo_swagger.OpenAPI, property *echo_swagger.Property) *echo_swagger.Property {
+	if property.Reference == "" {
+		return property
+	}
+
+	schema, ok := openapi.Components.Schemas[strings.TrimPrefix(property.Reference, echo_swagger.ComponentSchemasPrefix)]
+	if !ok {
+		return &echo_swagger.Property{}
+	}
+
+	return &schema.Property
+}
+
+// Returns the property of `items` & `additionalProperties`, which are properties in the generated specifications
+// and decoded maps in the loaded specifications, or nil whenever they aren't schemas (e.g. `additionalProperties: true`).
+func propertyOf(value interface{}) *echo_swagger.Property {
+	switch value := value.(type) {
+	case echo_swagger.Property:
+		return &value
+
+	case *echo_swagger.Property:
+		return value
+
+	case map[string]interface{}:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return nil
+		}
+
+		property := &echo_swagger.Property{}
+		if err := yaml.Unmarshal(data, property); err != nil {
+			return nil
+		}
+
+		return property
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/avivatedgi/echo-swagger/diff"
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	log "github.com/sirupsen/logrus"
+)
+
+// Run the `diff` subcommand, which compares two OpenAPI specifications and fails whenever they contain
+// breaking changes without a version bump.
+func runDiff(arguments []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	format := flags.String("format", string(diff.ReportFormatText), "Format of the report, `text` or json")
+
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: echo-swagger diff [--format text|json] <old> <new> (- reads the specifications from stdin)")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(arguments); err != nil {
+		log.Fatal(err)
+	} else if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	old, err := loadSpecifications(flags.Arg(0))
+	if err != nil {
+		log.Fatal("Failed to load the old specifications `", flags.Arg(0), "`, error = ", err)
+	}
+
+	new, err := loadSpecifications(flags.Arg(1))
+	if err != nil {
+		log.Fatal("Failed to load the new specifications `", flags.Arg(1), "`, error = ", err)
+	}
+
+	report := diff.Compare(old, new)
+
+	data, err := report.Marshal(diff.ReportFormat(*format))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if _, err := os.Stdout.Write(data); err != nil {
+		log.Fatal("Failed to write the report, error = ", err)
+	}
+
+	if report.Blocked() {
+		os.Exit(1)
+	}
+}
+
+func loadSpecifications(path string) (*echo_swagger.OpenAPI, error) {
+	var data []byte
+	var err error
+
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Load(data)
+}
@@ -14,6 +14,12 @@ func (attrs *commentAttributes) FromComments(comments string) error {
 
 	insertAttribute := func(attr string, value string) error {
 		if attr == "" {
+			// Text that doesn't belong to any attribute, keep it as the free text of the comments
+			if data != "" {
+				attrs.appendText(data)
+			}
+
+			data = ""
 			return nil
 		}
 
@@ -70,6 +76,11 @@ func (attrs *commentAttributes) FromComments(comments string) error {
 	return nil
 }
 
+// Returns the free text of the comments, which is all the text that is not part of any attribute.
+func (attrs commentAttributes) Text() string {
+	return attrs[TextAttribute]
+}
+
 func (attrs commentAttributes) HasKey(key string) bool {
 	_, exists := attrs[key]
 	return exists
@@ -109,3 +120,11 @@ func (attrs *commentAttributes) insertAttribute(key string, value string) error
 	(*attrs)[key] = value
 	return nil
 }
+
+func (attrs *commentAttributes) appendText(text string) {
+	if current := (*attrs)[TextAttribute]; current != "" {
+		text = current + " " + text
+	}
+
+	(*attrs)[TextAttribute] = text
+}
@@ -51,6 +51,20 @@ func TestAttributes(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			comments: `
+			The identifier of the user,
+			generated by the server.
+			@format uuid
+			@readOnly
+			`,
+			expectedAttrs: commentAttributes{
+				TextAttribute: "The identifier of the user, generated by the server.",
+				"format":      "uuid",
+				"readOnly":    "",
+			},
+			expectedError: nil,
+		},
 		{
 			comments: `
 			@route example
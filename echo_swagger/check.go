@@ -0,0 +1,225 @@
+package echo_swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type DifferenceKind string
+
+const (
+	DifferenceAdded   DifferenceKind = "+"
+	DifferenceRemoved DifferenceKind = "-"
+	DifferenceChanged DifferenceKind = "~"
+)
+
+// A semantic difference between an existing document and a generated document, see CompareDocuments.
+type Difference struct {
+	Kind DifferenceKind
+
+	// The keys (and the indexes, e.g. `[0]`) of the value that differs, from the root of the documents
+	Path []string
+
+	// The value in the existing document, nil whenever the value was added
+	Existing interface{}
+
+	// The value in the generated document, nil whenever the value was removed
+	Generated interface{}
+}
+
+// Matches the keys that can be written without quotes in the path of a difference
+var plainKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Returns the path of the difference, e.g. `paths."/users".get.parameters[0].name`.
+func (d Difference) Location() string {
+	return FormatLocation(d.Path)
+}
+
+// Format the keys (and the indexes, e.g. `[0]`) of a value in a document as a path, the keys that aren't
+// identifiers are quoted, e.g. `paths."/users".get.parameters[0].name`.
+func FormatLocation(path []string) string {
+	location := strings.Builder{}
+
+	for _, key := range path {
+		if strings.HasPrefix(key, "[") {
+			location.WriteString(key)
+			continue
+		} else if location.Len() > 0 {
+			location.WriteByte('.')
+		}
+
+		if !plainKeyPattern.MatchString(key) {
+			key = fmt.Sprintf("%q", key)
+		}
+
+		location.WriteString(key)
+	}
+
+	return location.String()
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DifferenceChanged:
+		return fmt.Sprintf("%s %s: %s => %s", d.Kind, d.Location(), describeValue(d.Existing), describeValue(d.Generated))
+
+	default:
+		return fmt.Sprintf("%s %s", d.Kind, d.Location())
+	}
+}
+
+// Returns a short description of a value, scalars are described by their JSON representation.
+func describeValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+
+	case []interface{}:
+		return "array"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
+
+// Compare an existing document with a generated document semantically, both of them may be either YAML or JSON
+// documents. Formatting differences (e.g. the format, the order of the keys, quotes and indentation) are ignored.
+func CompareDocuments(existing []byte, generated []byte) ([]Difference, error) {
+	var existingDocument, generatedDocument interface{}
+
+	if err := yaml.Unmarshal(existing, &existingDocument); err != nil {
+		return nil, wrapError(err, "failed to parse the existing document")
+	} else if err := yaml.Unmarshal(generated, &generatedDocument); err != nil {
+		return nil, wrapError(err, "failed to parse the generated document")
+	}
+
+	return compareValues([]string{}, normalizeValue(existingDocument), normalizeValue(generatedDocument)), nil
+}
+
+// Normalize a decoded value, so the values that are only formatted differently are equal: all the keys are
+// strings (e.g. unquoted status codes) and all the numbers are floats.
+func normalizeValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			normalized[key] = normalizeValue(item)
+		}
+
+		return normalized
+
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			normalized[fmt.Sprint(key)] = normalizeValue(item)
+		}
+
+		return normalized
+
+	case []interface{}:
+		normalized := make([]interface{}, len(value))
+		for index, item := range value {
+			normalized[index] = normalizeValue(item)
+		}
+
+		return normalized
+
+	case int:
+		return float64(value)
+
+	case int64:
+		return float64(value)
+
+	case uint64:
+		return float64(value)
+	}
+
+	return value
+}
+
+// Compare the values recursively, the values that were added or removed are reported as a whole.
+func compareValues(path []string, existing interface{}, generated interface{}) []Difference {
+	at := func(key string) []string {
+		return append(append([]string{}, path...), key)
+	}
+
+	switch existingValue := existing.(type) {
+	case map[string]interface{}:
+		generatedValue, ok := generated.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := []string{}
+		for key := range existingValue {
+			keys = append(keys, key)
+		}
+
+		for key := range generatedValue {
+			if _, exists := existingValue[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		differences := []Difference{}
+		for _, key := range keys {
+			existingItem, existsInExisting := existingValue[key]
+			generatedItem, existsInGenerated := generatedValue[key]
+
+			switch {
+			case !existsInExisting:
+				differences = append(differences, Difference{Kind: DifferenceAdded, Path: at(key), Generated: generatedItem})
+
+			case !existsInGenerated:
+				differences = append(differences, Difference{Kind: DifferenceRemoved, Path: at(key), Existing: existingItem})
+
+			default:
+				differences = append(differences, compareValues(at(key), existingItem, generatedItem)...)
+			}
+		}
+
+		return differences
+
+	case []interface{}:
+		generatedValue, ok := generated.([]interface{})
+		if !ok {
+			break
+		}
+
+		differences := []Difference{}
+		for index := 0; index < len(existingValue) || index < len(generatedValue); index++ {
+			key := fmt.Sprintf("[%d]", index)
+
+			switch {
+			case index >= len(existingValue):
+				differences = append(differences, Difference{Kind: DifferenceAdded, Path: at(key), Generated: generatedValue[index]})
+
+			case index >= len(generatedValue):
+				differences = append(differences, Difference{Kind: DifferenceRemoved, Path: at(key), Existing: existingValue[index]})
+
+			default:
+				differences = append(differences, compareValues(at(key), existingValue[index], generatedValue[index])...)
+			}
+		}
+
+		return differences
+	}
+
+	if reflect.DeepEqual(existing, generated) {
+		return nil
+	}
+
+	return []Difference{{Kind: DifferenceChanged, Path: path, Existing: existing, Generated: generated}}
+}
@@ -0,0 +1,106 @@
+package echo_swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareDocuments(t *testing.T) {
+	assert := assert.New(t)
+
+	existing := `
+openapi: 3.0.0
+paths:
+    /users:
+        get:
+            tags:
+                - Users
+            responses:
+                200:
+                    description: The users
+`
+
+	testCases := map[string]struct {
+		generated string
+		expected  []string
+	}{
+		"identical": {
+			generated: existing,
+			expected:  []string{},
+		},
+		"formatting": {
+			generated: `{"paths": {"/users": {"get": {"responses": {"200": {"description": "The users"}}, "tags": ["Users"]}}}, "openapi": "3.0.0"}`,
+			expected:  []string{},
+		},
+		"changed": {
+			generated: `
+openapi: 3.1.0
+paths:
+    /users:
+        get:
+            tags:
+                - Accounts
+            responses:
+                "200":
+                    description: The accounts
+`,
+			expected: []string{
+				`~ openapi: "3.0.0" => "3.1.0"`,
+				`~ paths."/users".get.responses."200".description: "The users" => "The accounts"`,
+				`~ paths."/users".get.tags[0]: "Users" => "Accounts"`,
+			},
+		},
+		"added": {
+			generated: `
+openapi: 3.0.0
+paths:
+    /users:
+        get:
+            tags:
+                - Users
+                - Admin
+            responses:
+                "200":
+                    description: The users
+        post:
+            responses:
+                "201":
+                    description: The user
+`,
+			expected: []string{
+				`+ paths."/users".get.tags[1]`,
+				`+ paths."/users".post`,
+			},
+		},
+		"type": {
+			generated: `
+openapi: 3.0.0
+paths:
+    /users: []
+`,
+			expected: []string{
+				`~ paths."/users": object => array`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		differences, err := CompareDocuments([]byte(existing), []byte(testCase.generated))
+		assert.NoError(err, name)
+
+		actual := []string{}
+		for _, difference := range differences {
+			actual = append(actual, difference.String())
+		}
+
+		assert.Equal(testCase.expected, actual, name)
+	}
+
+	differences, err := CompareDocuments([]byte(`paths: {}`), []byte(`{}`))
+	assert.NoError(err)
+	assert.Equal([]Difference{{Kind: DifferenceRemoved, Path: []string{"paths"}, Existing: map[string]interface{}{}}}, differences)
+
+	_, err = CompareDocuments([]byte(`paths: [`), []byte(`{}`))
+	assert.Error(err)
+}
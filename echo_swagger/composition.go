@@ -0,0 +1,186 @@
+package echo_swagger
+
+import (
+	"go/types"
+	"strings"
+)
+
+// The attributes that declare the subschemas of a composition, by the keyword they are described with.
+var compositionAttributes = []string{OneOfAttribute, AnyOfAttribute, AllOfAttribute}
+
+// Returns the schema of a composition that was declared by the attributes, e.g. `@oneOf Cat Dog` with an optional
+// `@discriminator kind` (or `@discriminator kind cat=Cat dog=Dog` for an explicit mapping). The names of the types are
+// resolved in the package the attributes were declared in, types of imported packages can be used as `pkg.Type`.
+func (context *Context) parseComposition(attributes commentAttributes, pkg *types.Package, tag string) (*Property, bool, error) {
+	keyword := ""
+	for _, attribute := range compositionAttributes {
+		if !attributes.HasKey(attribute) {
+			continue
+		} else if keyword != "" {
+			return nil, false, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: attribute}, Value: "only one of oneOf, anyOf and allOf can be used"}
+		}
+
+		keyword = attribute
+	}
+
+	if keyword == "" {
+		return nil, false, nil
+	}
+
+	property := Property{}
+	subtypes := map[string]*types.TypeName{}
+	subschemas := []Property{}
+
+	for _, typeName := range parseStringByQuotesAndSpaces(attributes[keyword]) {
+		obj := lookupTypeName(pkg, typeName)
+		if obj == nil {
+			return nil, false, TypeNotFoundError{TypeName: typeName}
+		}
+
+		subschema, err := context.parseProperty(obj.Type(), tag)
+		if err != nil {
+			return nil, false, wrapError(err, "failed to parse `%s`", typeName)
+		} else if subschema == nil {
+			continue
+		}
+
+		subtypes[typeName] = obj
+		subschemas = append(subschemas, *subschema)
+	}
+
+	switch keyword {
+	case OneOfAttribute:
+		property.OneOf = subschemas
+
+	case AnyOfAttribute:
+		property.AnyOf = subschemas
+
+	case AllOfAttribute:
+		property.AllOf = subschemas
+	}
+
+	if !attributes.HasKey(DiscriminatorAttribute) {
+		return &property, true, nil
+	}
+
+	discriminator := parseStringByQuotesAndSpaces(attributes[DiscriminatorAttribute])
+	if len(discriminator) == 0 {
+		return nil, false, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: DiscriminatorAttribute}, Value: attributes[DiscriminatorAttribute]}
+	}
+
+	property.Discriminator = Discriminator{PropertyName: discriminator[0], Mapping: map[string]string{}}
+
+	if len(discriminator) > 1 {
+		// An explicit mapping between the values and the types
+		for _, item := range discriminator[1:] {
+			value, typeName, found := strings.Cut(item, "=")
+			if !found {
+				return nil, false, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: DiscriminatorAttribute}, Value: attributes[DiscriminatorAttribute]}
+			}
+
+			obj, exists := subtypes[typeName]
+			if !exists {
+				return nil, false, TypeNotFoundError{TypeName: typeName}
+			}
+
+			if reference := context.componentReference(obj); reference != "" {
+				property.Discriminator.Mapping[value] = reference
+			}
+		}
+
+		return &property, true, nil
+	}
+
+	// The values are taken from the `@discriminatorValue` attribute of the types, or their names by default
+	for _, obj := range subtypes {
+		reference := context.componentReference(obj)
+		if reference == "" {
+			continue
+		}
+
+		typeAttributes, err := context.objectAttributes(obj)
+		if err != nil {
+			return nil, false, err
+		}
+
+		value := typeAttributes.GetOrDefault(DiscriminatorValueAttribute)
+		if value == "" {
+			value = obj.Name()
+		}
+
+		property.Discriminator.Mapping[value] = reference
+	}
+
+	return &property, true, nil
+}
+
+// Returns the schema of an interface type that declares its implementations with a composition attribute.
+// The composition is registered as a component, so it can be referenced by the implementations themselves.
+func (context *Context) parseInterface(named *types.Named, name string, tag string) (*Property, bool, error) {
+	if _, exists := context.OpenAPI.Components.Schemas[name]; exists && tag == JsonTag {
+		return &Property{Reference: ComponentSchemasPrefix + name}, true, nil
+	}
+
+	attributes, err := context.objectAttributes(named.Obj())
+	if err != nil {
+		return nil, false, err
+	}
+
+	context.visiting[name] = false
+	defer delete(context.visiting, name)
+
+	property, ok, err := context.parseComposition(attributes, named.Obj().Pkg(), tag)
+	if err != nil || !ok {
+		return nil, ok, err
+	} else if tag != JsonTag && !context.visiting[name] {
+		return property, true, nil
+	}
+
+	property.Description = attributes.Text()
+	context.registerComponent(name, property)
+	return &Property{Reference: ComponentSchemasPrefix + name}, true, nil
+}
+
+// Returns the reference to the component of a type, or an empty string if the type is not a component.
+func (context *Context) componentReference(obj *types.TypeName) string {
+	named, ok := types.Unalias(obj.Type()).(*types.Named)
+	if !ok {
+		return ""
+	}
+
+	name := context.componentName(named)
+	if _, exists := context.OpenAPI.Components.Schemas[name]; !exists {
+		return ""
+	}
+
+	return ComponentSchemasPrefix + name
+}
+
+// Find a type by its name in the scope of the package, types of imported packages are named as `pkg.Type`.
+func lookupTypeName(pkg *types.Package, name string) *types.TypeName {
+	if pkg == nil {
+		return nil
+	}
+
+	scope := pkg.Scope()
+
+	if pkgName, typeName, found := strings.Cut(name, "."); found {
+		scope = nil
+
+		for _, imported := range pkg.Imports() {
+			if imported.Name() == pkgName {
+				scope = imported.Scope()
+				break
+			}
+		}
+
+		if scope == nil {
+			return nil
+		}
+
+		name = typeName
+	}
+
+	obj, _ := scope.Lookup(name).(*types.TypeName)
+	return obj
+}
@@ -0,0 +1,105 @@
+package echo_swagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The configuration of the generated document, which is read from the info file.
+// The info fields are inlined, so a plain info file is a valid configuration too.
+type Config struct {
+	Info `yaml:",inline"`
+
+	// The security schemes that can be required by the handlers with the `@security` attribute, by their names.
+	SecuritySchemes map[string]SecurityScheme `yaml:"securitySchemes,omitempty" json:"securitySchemes,omitempty"`
+
+	// The security requirements of all the operations, unless they are overridden by the `@security` attribute.
+	Security []SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+
+	// The response of the operations that don't declare any response, without it such operations fail the parsing.
+	DefaultResponse *DefaultResponse `yaml:"defaultResponse,omitempty" json:"defaultResponse,omitempty"`
+}
+
+// A response without content that is added to the operations that don't declare any response.
+type DefaultResponse struct {
+	// The status code of the response, e.g. `200` or `default`.
+	StatusCode string `yaml:"statusCode" json:"statusCode"`
+
+	// A short description of the response.
+	Description string `yaml:"description" json:"description"`
+}
+
+// Apply the configuration on the generated document, should be called before parsing the handlers
+// so their security requirements can be checked against the declared schemes.
+func (context *Context) ApplyConfig(config Config) error {
+	for name, scheme := range config.SecuritySchemes {
+		if err := scheme.Validate(); err != nil {
+			return wrapError(err, "security scheme `%s`", name)
+		}
+	}
+
+	if config.DefaultResponse != nil {
+		if err := validateStatusCode(config.DefaultResponse.StatusCode); err != nil {
+			return wrapError(err, "default response")
+		} else if config.DefaultResponse.Description == "" {
+			return wrapError(MissingAttributeError{AttributeError: AttributeError{AttributeName: DescriptionAttribute}}, "default response")
+		}
+	}
+
+	context.OpenAPI.Info = config.Info
+	context.defaultResponse = config.DefaultResponse
+	context.OpenAPI.Components.SecuritySchemes = config.SecuritySchemes
+
+	for _, requirement := range config.Security {
+		if err := context.validateSecurityRequirement(requirement); err != nil {
+			return err
+		}
+	}
+
+	if config.Security != nil {
+		context.OpenAPI.Security = config.Security
+	}
+
+	return nil
+}
+
+// Parse the security requirements of an operation from the `@security` attribute, alternative requirements
+// are separated by `|`, and every requirement is a scheme name followed by its scopes, e.g. `OAuth2 read:users | ApiKey`.
+// The value `none` removes the top-level security requirements from the operation.
+func (context *Context) parseSecurity(value string) (*[]SecurityRequirement, error) {
+	requirements := []SecurityRequirement{}
+
+	if value == SecurityNone {
+		return &requirements, nil
+	}
+
+	for _, alternative := range strings.Split(value, "|") {
+		items := strings.Fields(alternative)
+		if len(items) == 0 {
+			return nil, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SecurityAttribute}, Value: value}
+		}
+
+		requirement := SecurityRequirement{items[0]: items[1:]}
+		if err := context.validateSecurityRequirement(requirement); err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return &requirements, nil
+}
+
+// Check that the schemes of the requirement were declared, and that scopes are required only by schemes that support them.
+func (context *Context) validateSecurityRequirement(requirement SecurityRequirement) error {
+	for name, scopes := range requirement {
+		scheme, exists := context.OpenAPI.Components.SecuritySchemes[name]
+		if !exists {
+			return UnknownSecuritySchemeError{Name: name}
+		} else if len(scopes) > 0 && !scheme.HasScopes() {
+			return InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SecurityAttribute}, Value: fmt.Sprintf("scheme `%s` of type `%s` can't require scopes", name, scheme.Type)}
+		}
+	}
+
+	return nil
+}
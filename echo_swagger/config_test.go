@@ -0,0 +1,117 @@
+package echo_swagger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCase struct {
+		scheme          SecurityScheme
+		security        []SecurityRequirement
+		defaultResponse *DefaultResponse
+		expectedError   error
+	}
+
+	testCases := []testCase{
+		{
+			scheme: SecurityScheme{Type: SecuritySchemeTypeHttp, Scheme: "bearer", BearerFormat: "JWT"},
+		},
+		{
+			scheme:        SecurityScheme{Type: SecuritySchemeTypeHttp},
+			expectedError: InvalidSecuritySchemeError{Reason: "missing `scheme` of http"},
+		},
+		{
+			scheme:        SecurityScheme{Type: SecuritySchemeTypeApiKey, Name: "token", In: ParameterLocationPath},
+			expectedError: InvalidSecuritySchemeError{Reason: "invalid location `path` of apiKey"},
+		},
+		{
+			scheme:        SecurityScheme{Type: SecuritySchemeTypeOAuth2},
+			expectedError: InvalidSecuritySchemeError{Reason: "missing `flows` of oauth2"},
+		},
+		{
+			scheme:        SecurityScheme{Type: SecuritySchemeTypeOpenIdConnect, OpenIdConnectUrl: "https://example.com/.well-known/openid-configuration"},
+			security:      []SecurityRequirement{{"Scheme": {"openid"}}, {"Missing": {}}},
+			expectedError: UnknownSecuritySchemeError{Name: "Missing"},
+		},
+		{
+			scheme:          SecurityScheme{Type: SecuritySchemeTypeHttp, Scheme: "basic"},
+			defaultResponse: &DefaultResponse{StatusCode: "2XX", Description: "A success response"},
+		},
+		{
+			scheme:          SecurityScheme{Type: SecuritySchemeTypeHttp, Scheme: "basic"},
+			defaultResponse: &DefaultResponse{StatusCode: "OK", Description: "A success response"},
+			expectedError:   InvalidStatusCodeError{StatusCode: "OK"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		context := NewContext()
+		err := context.ApplyConfig(Config{
+			Info:            generalInfo(),
+			SecuritySchemes: map[string]SecurityScheme{"Scheme": testCase.scheme},
+			Security:        testCase.security,
+			DefaultResponse: testCase.defaultResponse,
+		})
+
+		if testCase.expectedError != nil {
+			assert.True(errors.Is(err, testCase.expectedError), err)
+		} else {
+			assert.NoError(err)
+		}
+	}
+}
+
+func TestParseSecurity(t *testing.T) {
+	assert := assert.New(t)
+
+	context := NewContext()
+	assert.NoError(context.ApplyConfig(generalConfig()))
+
+	type testCase struct {
+		value         string
+		expected      *[]SecurityRequirement
+		expectedError error
+	}
+
+	testCases := []testCase{
+		{
+			value:    "ApiKey",
+			expected: &[]SecurityRequirement{{"ApiKey": {}}},
+		},
+		{
+			value:    "OAuth2 read:users write:users | ApiKey",
+			expected: &[]SecurityRequirement{{"OAuth2": {"read:users", "write:users"}}, {"ApiKey": {}}},
+		},
+		{
+			value:    "none",
+			expected: &[]SecurityRequirement{},
+		},
+		{
+			value:         "BearerAuth",
+			expectedError: UnknownSecuritySchemeError{Name: "BearerAuth"},
+		},
+		{
+			value:         "ApiKey read:users",
+			expectedError: InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SecurityAttribute}, Value: "scheme `ApiKey` of type `apiKey` can't require scopes"},
+		},
+		{
+			value:         "ApiKey |",
+			expectedError: InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SecurityAttribute}, Value: "ApiKey |"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		security, err := context.parseSecurity(testCase.value)
+
+		if testCase.expectedError != nil {
+			assert.True(errors.Is(err, testCase.expectedError), err)
+		} else if assert.NoError(err) {
+			assert.Equal(testCase.expected, security)
+		}
+	}
+}
@@ -10,19 +10,69 @@ const (
 	OperationIdAttribute = "operationId"
 	TagsAttribute        = "tags"
 	ResponseAttribute    = "response"
+	ExampleAttribute     = "example"
+	EnumAttribute        = "enum"
+	FormatAttribute      = "format"
+	DefaultAttribute     = "default"
+	ReadOnlyAttribute    = "readOnly"
+	WriteOnlyAttribute   = "writeOnly"
+	SecurityAttribute    = "security"
+	AcceptAttribute      = "accept"
+	ProduceAttribute     = "produce"
+	ContentTypeAttribute = "contentType"
+	SchemaAttribute      = "schema"
+	TypeAttribute        = "type"
+	WebhookAttribute     = "webhook"
+
+	OneOfAttribute              = "oneOf"
+	AnyOfAttribute              = "anyOf"
+	AllOfAttribute              = "allOf"
+	DiscriminatorAttribute      = "discriminator"
+	DiscriminatorValueAttribute = "discriminatorValue"
+	SharedResponseAttribute     = "sharedResponse"
+
+	// The free text of the comments is stored under an empty attribute name
+	TextAttribute = ""
 
 	BinderTag             = "binder"
 	JsonTag               = "json"
+	FormTag               = "form"
+	XmlTag                = "xml"
 	ValidateTag           = "validate"
 	ValidateRequiredValue = "required"
+	ValidateOmitEmpty     = "omitempty"
+	ValidateDive          = "dive"
+	ValidateKeys          = "keys"
+	ValidateEndKeys       = "endkeys"
 
 	ResponseFieldSuffix = "Response"
 	PathField           = "Path"
 	BodyField           = "Body"
 	HeaderField         = "Header"
 	QueryField          = "Query"
+	CookieField         = "Cookie"
+
+	OpenApiVersion   = "3.0.0"
+	OpenApiVersion31 = "3.1.0"
+
+	// The JSON Schema dialect of the schemas in OpenAPI 3.1 documents
+	JsonSchemaDialect31 = "https://spec.openapis.org/oas/3.1/dialect/base"
+
+	// The value of the security attribute that removes the top-level security requirements from an operation
+	SecurityNone = "none"
+
+	ComponentSchemasPrefix   = "#/components/schemas/"
+	ComponentResponsesPrefix = "#/components/responses/"
+
+	// The status code of the response that describes all the status codes that are not declared explicitly
+	DefaultStatusCode = "default"
 
-	OpenApiVersion = "3.0.0"
+	ContentTypeJson           = "application/json"
+	ContentTypeMultipartForm  = "multipart/form-data"
+	ContentTypeUrlEncodedForm = "application/x-www-form-urlencoded"
+	ContentTypeXml            = "application/xml"
+	ContentTypeTextXml        = "text/xml"
 
-	ContentTypeJson = "application/json"
+	// The name of the field that holds the name of the XML element, which is not a part of the content
+	XmlNameField = "XMLName"
 )
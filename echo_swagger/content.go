@@ -0,0 +1,76 @@
+package echo_swagger
+
+import (
+	"go/types"
+	"strings"
+)
+
+// Returns the tag that names the fields of a body with the content type: `form` for forms,
+// `xml` for XML documents and `json` for anything else.
+func contentTypeTag(contentType string) string {
+	switch {
+	case contentType == ContentTypeMultipartForm || contentType == ContentTypeUrlEncodedForm:
+		return FormTag
+
+	case isXmlContentType(contentType):
+		return XmlTag
+
+	default:
+		return JsonTag
+	}
+}
+
+// Check whether the content type is of an XML document, e.g. `application/xml`, `text/xml` or `application/atom+xml`.
+func isXmlContentType(contentType string) bool {
+	return contentType == ContentTypeXml || contentType == ContentTypeTextXml || strings.HasSuffix(contentType, "+xml")
+}
+
+// Parse the content types of an attribute, which are separated by spaces.
+func parseContentTypes(attributes commentAttributes, attribute string) ([]string, error) {
+	contentTypes := parseStringByQuotesAndSpaces(attributes[attribute])
+	if len(contentTypes) == 0 {
+		return nil, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: attribute}, Value: attributes[attribute]}
+	}
+
+	return contentTypes, nil
+}
+
+// Returns the schema of a body that is sent as raw binary data with the content type, which is
+// the case for readers (e.g. `io.Reader` or `*os.File`) and byte slices that are not encoded as JSON.
+func binaryProperty(t types.Type, contentType string) (*Property, bool) {
+	binary := &Property{Type: PropertyType_String, Format: PropertyFormat_Binary}
+
+	if isReader(t) {
+		return binary, true
+	} else if slice, ok := t.Underlying().(*types.Slice); ok && contentType != ContentTypeJson {
+		if elem, ok := slice.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Byte {
+			return binary, true
+		}
+	}
+
+	return nil, false
+}
+
+// Check whether the type implements io.Reader, i.e. has the method `Read([]byte) (int, error)`.
+func isReader(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Read")
+	method, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	signature := method.Type().(*types.Signature)
+	if signature.Params().Len() != 1 || signature.Results().Len() != 2 {
+		return false
+	}
+
+	data, ok := signature.Params().At(0).Type().(*types.Slice)
+	if !ok {
+		return false
+	} else if elem, ok := data.Elem().(*types.Basic); !ok || elem.Kind() != types.Byte {
+		return false
+	}
+
+	return types.Identical(signature.Results().At(0).Type(), types.Typ[types.Int]) &&
+		types.Identical(signature.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,322 @@
+package echo_swagger
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/tools/go/packages"
+)
+
+// The paths of the echo packages whose `Echo` and `Group` types register the routes
+var echoPackages = map[string]bool{
+	"github.com/labstack/echo":    true,
+	"github.com/labstack/echo/v4": true,
+}
+
+// The methods of `echo.Echo` and `echo.Group` that register a route with a single HTTP method
+var echoRouteMethods = map[string]bool{
+	"GET":     true,
+	"PUT":     true,
+	"POST":    true,
+	"DELETE":  true,
+	"OPTIONS": true,
+	"HEAD":    true,
+	"PATCH":   true,
+	"TRACE":   true,
+}
+
+// A route that was found by the registration of its handler, e.g. `e.PUT("/users/:id", Handle[UpdateUserRequest])`.
+type discoveredRoute struct {
+	route  string
+	method string
+}
+
+// Find the routes that are registered on `echo.Echo` and `echo.Group` in the package, and map them to the request types of their handlers.
+// The prefixes of the groups are resolved as long as the groups are assigned to variables (or fields) in the same package,
+// the routes of the other groups (e.g. a group that is passed as a parameter) are skipped with a warning.
+func (context *Context) discoverRoutes(pkg *packages.Package) {
+	prefixes := map[types.Object]string{}
+
+	// The groups are found before the routes, so the routes of a group are resolved wherever the group is created
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if len(node.Lhs) == len(node.Rhs) {
+					for index, rhs := range node.Rhs {
+						context.discoverGroup(pkg, prefixes, node.Lhs[index], rhs)
+					}
+				}
+
+			case *ast.ValueSpec:
+				if len(node.Names) == len(node.Values) {
+					for index, value := range node.Values {
+						context.discoverGroup(pkg, prefixes, node.Names[index], value)
+					}
+				}
+			}
+
+			return true
+		})
+	}
+
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				context.discoverRoute(pkg, prefixes, call)
+			}
+
+			return true
+		})
+	}
+}
+
+// Remember the prefix of a group that is assigned to a variable, e.g. `users := e.Group("/users")`.
+func (context *Context) discoverGroup(pkg *packages.Package, prefixes map[types.Object]string, lhs ast.Expr, rhs ast.Expr) {
+	call, ok := rhs.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	prefix, ok := groupPrefix(pkg, prefixes, call)
+	if !ok {
+		return
+	}
+
+	if obj := assignedObject(pkg, lhs); obj != nil {
+		prefixes[obj] = prefix
+	}
+}
+
+// Register the route of a call to one of the route methods of echo, e.g. `e.GET(path, handler)` or `e.Add(method, path, handler)`.
+func (context *Context) discoverRoute(pkg *packages.Package, prefixes map[types.Object]string, call *ast.CallExpr) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !isEchoRouter(pkg.TypesInfo.TypeOf(selector.X)) {
+		return
+	}
+
+	args := call.Args
+	method := selector.Sel.Name
+
+	if method == "Add" && len(args) >= 3 {
+		value, ok := stringConstant(pkg, args[0])
+		if !ok {
+			return
+		}
+
+		method, args = strings.ToUpper(value), args[1:]
+	}
+
+	if !echoRouteMethods[method] || len(args) < 2 {
+		return
+	}
+
+	path, ok := stringConstant(pkg, args[0])
+	if !ok {
+		log.Debug(context.position(call.Pos()), ": route with a non-constant path, skipping...")
+		return
+	}
+
+	named := context.handlerRequestType(pkg, args[1])
+	if named == nil {
+		log.Debug(context.position(call.Pos()), ": handler of route `", path, "` without a request type, skipping...")
+		return
+	}
+
+	prefix, ok := routerPrefix(pkg, prefixes, selector.X)
+	if !ok {
+		log.Warning(context.position(call.Pos()), ": route `", path, "` is registered on a group whose prefix is unknown (e.g. a group parameter), skipping...")
+		return
+	}
+
+	name := qualifiedTypeName(named)
+	route := discoveredRoute{route: prefix + path, method: method}
+
+	for _, existing := range context.discoveredRoutes[name] {
+		if existing == route {
+			return
+		}
+	}
+
+	context.discoveredRoutes[name] = append(context.discoveredRoutes[name], route)
+}
+
+// Returns the prefix of the group that is created by the call, e.g. `e.Group("/users")`, including the prefixes of its parents.
+func groupPrefix(pkg *packages.Package, prefixes map[types.Object]string, call *ast.CallExpr) (string, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "Group" || len(call.Args) == 0 || !isEchoRouter(pkg.TypesInfo.TypeOf(selector.X)) {
+		return "", false
+	}
+
+	prefix, ok := stringConstant(pkg, call.Args[0])
+	if !ok {
+		return "", false
+	}
+
+	parent, ok := routerPrefix(pkg, prefixes, selector.X)
+	if !ok {
+		return "", false
+	}
+
+	return parent + prefix, true
+}
+
+// Returns the prefix of the routes that are registered on the router expression, which is empty for `echo.Echo`.
+// The prefix of a group is unknown (and false is returned) whenever its creation was not found, e.g. for a group parameter.
+func routerPrefix(pkg *packages.Package, prefixes map[types.Object]string, router ast.Expr) (string, bool) {
+	switch router := router.(type) {
+	case *ast.ParenExpr:
+		return routerPrefix(pkg, prefixes, router.X)
+
+	case *ast.CallExpr:
+		return groupPrefix(pkg, prefixes, router)
+
+	default:
+		if obj := assignedObject(pkg, router); obj != nil {
+			if prefix, ok := prefixes[obj]; ok {
+				return prefix, true
+			}
+		}
+
+		return "", !isEchoGroup(pkg.TypesInfo.TypeOf(router))
+	}
+}
+
+// Returns the object of a variable or a field that is assigned or used, e.g. `users` or `server.users`.
+func assignedObject(pkg *packages.Package, expr ast.Expr) types.Object {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return pkg.TypesInfo.ObjectOf(expr)
+
+	case *ast.SelectorExpr:
+		return pkg.TypesInfo.ObjectOf(expr.Sel)
+
+	default:
+		return nil
+	}
+}
+
+// Check whether the type is `echo.Echo` or `echo.Group` (or a pointer to them).
+func isEchoRouter(t types.Type) bool {
+	name := echoTypeName(t)
+	return name == "Echo" || name == "Group"
+}
+
+// Check whether the type is `echo.Group` (or a pointer to it).
+func isEchoGroup(t types.Type) bool {
+	return echoTypeName(t) == "Group"
+}
+
+// Returns the name of the type (or of the type it points to) if it is declared by echo, or an empty string otherwise.
+func echoTypeName(t types.Type) string {
+	if t == nil {
+		return ""
+	} else if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || !echoPackages[named.Obj().Pkg().Path()] {
+		return ""
+	}
+
+	return named.Obj().Name()
+}
+
+// Returns the value of a constant string expression, e.g. `"/users"` or `http.MethodGet`.
+func stringConstant(pkg *packages.Package, expr ast.Expr) (string, bool) {
+	value := pkg.TypesInfo.Types[expr].Value
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+
+	return constant.StringVal(value), true
+}
+
+// Returns the request type of a handler expression, which is the first request handler structure (see
+// isRequestHandler) that is used by the expression, e.g. `Handle[UpdateUserRequest]`,
+// `Wrap(&UpdateUserRequest{})` or `(&UpdateUserRequest{}).Handle`.
+func (context *Context) handlerRequestType(pkg *packages.Package, handler ast.Expr) *types.Named {
+	var request *types.Named
+
+	ast.Inspect(handler, func(n ast.Node) bool {
+		if request != nil {
+			return false
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if ok {
+			if instance, ok := pkg.TypesInfo.Instances[ident]; ok {
+				for index := 0; index < instance.TypeArgs.Len() && request == nil; index++ {
+					request = context.requestType(instance.TypeArgs.At(index))
+				}
+			}
+		}
+
+		if expr, ok := n.(ast.Expr); ok && request == nil {
+			if tv, ok := pkg.TypesInfo.Types[expr]; ok {
+				request = context.requestType(tv.Type)
+			}
+		}
+
+		return request == nil
+	})
+
+	return request
+}
+
+// Returns the named structure of the type (or of the type it points to) if it is a request handler.
+func (context *Context) requestType(t types.Type) *types.Named {
+	if pointer, ok := t.(*types.Pointer); ok {
+		t = pointer.Elem()
+	}
+
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || !context.isRequestHandler(named) {
+		return nil
+	}
+
+	return named
+}
+
+// Returns the attributes of the handlers of a request structure. The routes that were discovered for the structure
+// fill the `@route` and `@method` attributes that are missing, while the explicit attributes win.
+func (context *Context) handlerAttributes(named *types.Named, attributes commentAttributes) []commentAttributes {
+	routes := context.discoveredRoutes[qualifiedTypeName(named)]
+	if len(routes) == 0 || (attributes.HasKey(RouteAttribute) && attributes.HasKey(MethodAttribute)) {
+		return []commentAttributes{attributes}
+	}
+
+	handlers := []commentAttributes{}
+	seen := map[discoveredRoute]bool{}
+
+	for _, route := range routes {
+		if attributes.HasKey(RouteAttribute) {
+			route.route = attributes[RouteAttribute]
+		}
+
+		if attributes.HasKey(MethodAttribute) {
+			route.method = attributes[MethodAttribute]
+		}
+
+		if seen[route] {
+			continue
+		}
+
+		seen[route] = true
+
+		handler := make(commentAttributes)
+		for key, value := range attributes {
+			handler[key] = value
+		}
+
+		handler[RouteAttribute] = route.route
+		handler[MethodAttribute] = route.method
+		handlers = append(handlers, handler)
+	}
+
+	return handlers
+}
@@ -0,0 +1,46 @@
+package echo_swagger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverGroupParameter(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	context := NewContext()
+	context.DiscoverRoutes = true
+
+	if !assert.NoError(context.ParseDirectory("../testdata/discovery", "./...")) {
+		return
+	}
+
+	// The route of the group parameter must not be generated without the prefix of the group
+	assert.NotContains(context.OpenAPI.Paths, "/{id}")
+	assert.NotContains(context.OpenAPI.Paths, "/api/profiles/{id}")
+	assert.Empty(context.discoveredRoutes["example.com/discovery.GetProfileRequest"])
+
+	path, err := filepath.Abs("../testdata/discovery/groups.go")
+	if !assert.NoError(err) {
+		return
+	}
+
+	warnings := []string{}
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && strings.HasPrefix(entry.Message, path) {
+			warnings = append(warnings, strings.ReplaceAll(entry.Message, path, "groups.go"))
+		}
+	}
+
+	assert.Equal([]string{
+		"groups.go:19:2: route `/:id` is registered on a group whose prefix is unknown (e.g. a group parameter), skipping...",
+	}, warnings)
+}
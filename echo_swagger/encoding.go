@@ -0,0 +1,463 @@
+package echo_swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// The format that the OpenAPI specifications are written in.
+type Format string
+
+const (
+	FormatYaml Format = "yaml"
+	FormatJson Format = "json"
+)
+
+// Validate that the format is one of the known formats.
+func (format Format) Validate() error {
+	switch format {
+	case FormatYaml, FormatJson:
+		return nil
+	}
+
+	return InvalidFormatError{Format: string(format)}
+}
+
+// Returns the format of an output file by its extension, YAML is used for all the files that aren't `.json` files.
+func FormatFromPath(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return FormatJson
+	}
+
+	return FormatYaml
+}
+
+// Returns the full OpenAPI version of a version that may omit its patch number, e.g. `3.1` for `3.1.0`.
+func ParseOpenApiVersion(version string) (string, error) {
+	switch version {
+	case "3.0", OpenApiVersion:
+		return OpenApiVersion, nil
+
+	case "3.1", OpenApiVersion31:
+		return OpenApiVersion31, nil
+	}
+
+	return "", InvalidOpenApiVersionError{Version: version}
+}
+
+// Whether the document is emitted with the semantics of OpenAPI 3.1, see OpenAPI.Marshal.
+func (openapi OpenAPI) IsOpenApi31() bool {
+	return strings.HasPrefix(openapi.OpenAPI, "3.1")
+}
+
+// Marshal the OpenAPI specifications in the given format. The specifications are generated with the semantics of
+// OpenAPI 3.0, and they are converted to the semantics of OpenAPI 3.1 whenever the document version is 3.1.
+// The webhooks are dropped (with a warning) from OpenAPI 3.0 documents, which can't describe them.
+func (openapi OpenAPI) Marshal(format Format) ([]byte, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+
+	if openapi.IsOpenApi31() {
+		if openapi.JsonSchemaDialect == "" {
+			openapi.JsonSchemaDialect = JsonSchemaDialect31
+		}
+	} else if len(openapi.Webhooks) > 0 {
+		log.Warning("webhooks are not supported by OpenAPI ", openapi.OpenAPI, ", ", len(openapi.Webhooks), " webhooks are dropped (use OpenAPI 3.1)")
+		openapi.Webhooks = nil
+	}
+
+	node, err := encodeDocument(openapi)
+	if err != nil {
+		return nil, err
+	}
+
+	if openapi.IsOpenApi31() {
+		convertToOpenApi31(node)
+	}
+
+	return marshalNode(node, format)
+}
+
+// Marshal a document (e.g. the specifications in another version) in the given format, with the same
+// semantics as the OpenAPI specifications: the fields are named and omitted by their `yaml` tags.
+func MarshalDocument(document interface{}, format Format) ([]byte, error) {
+	if err := format.Validate(); err != nil {
+		return nil, err
+	}
+
+	node, err := encodeDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalNode(node, format)
+}
+
+// The document is encoded into YAML nodes first, so both of the formats omit the same empty values and keep the same order.
+func encodeDocument(document interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(document); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+func marshalNode(node *yaml.Node, format Format) ([]byte, error) {
+	if format == FormatJson {
+		return marshalJson(node)
+	}
+
+	return yaml.Marshal(node)
+}
+
+// Write the YAML nodes as an indented JSON document.
+func marshalJson(node *yaml.Node) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	if err := writeJson(buffer, node); err != nil {
+		return nil, err
+	}
+
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, buffer.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+
+	indented.WriteByte('\n')
+	return indented.Bytes(), nil
+}
+
+func writeJson(buffer *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, content := range node.Content {
+			if err := writeJson(buffer, content); err != nil {
+				return err
+			}
+		}
+
+	case yaml.AliasNode:
+		return writeJson(buffer, node.Alias)
+
+	case yaml.MappingNode:
+		buffer.WriteByte('{')
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			if index > 0 {
+				buffer.WriteByte(',')
+			}
+
+			// The keys of JSON objects are always strings, e.g. the status codes of the responses
+			if err := writeJsonValue(buffer, node.Content[index].Value); err != nil {
+				return err
+			}
+
+			buffer.WriteByte(':')
+			if err := writeJson(buffer, node.Content[index+1]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for index, content := range node.Content {
+			if index > 0 {
+				buffer.WriteByte(',')
+			}
+
+			if err := writeJson(buffer, content); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+
+	default:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+
+		return writeJsonValue(buffer, value)
+	}
+
+	return nil
+}
+
+func writeJsonValue(buffer *bytes.Buffer, value interface{}) error {
+	encoder := json.NewEncoder(buffer)
+
+	// Descriptions may contain HTML (CommonMark), which shouldn't be escaped
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+
+	// The encoder terminates every value with a new line
+	buffer.Truncate(buffer.Len() - 1)
+	return nil
+}
+
+// Convert the encoded OpenAPI 3.0 document into the semantics of OpenAPI 3.1, by converting all of its schemas
+// (the `schema` of the parameters, headers & media types and the component schemas).
+func convertToOpenApi31(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, content := range node.Content {
+			convertToOpenApi31(content)
+		}
+
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			key, value := node.Content[index].Value, node.Content[index+1]
+
+			switch key {
+			case "schema":
+				convertSchemaToOpenApi31(value)
+
+			case "schemas":
+				for schema := 1; schema < len(value.Content); schema += 2 {
+					convertSchemaToOpenApi31(value.Content[schema])
+				}
+
+			default:
+				convertToOpenApi31(value)
+			}
+		}
+	}
+}
+
+// Convert a schema (and its sub-schemas) into the semantics of OpenAPI 3.1 (JSON Schema 2020-12):
+// `nullable` is replaced with a `null` type, `example` with `examples` and the boolean exclusive bounds with numeric ones.
+func convertSchemaToOpenApi31(schema *yaml.Node) {
+	if schema.Kind != yaml.MappingNode {
+		return
+	}
+
+	if nullable := removeMappingValue(schema, "nullable"); nullable != nil && nullable.Value == "true" {
+		makeNullable(schema)
+	}
+
+	if index := mappingIndex(schema, "example"); index >= 0 {
+		schema.Content[index].Value = "examples"
+		schema.Content[index+1] = sequenceNode(schema.Content[index+1])
+	}
+
+	for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		value := removeMappingValue(schema, exclusive)
+		if index := mappingIndex(schema, bound); index >= 0 && value != nil && value.Value == "true" {
+			schema.Content[index].Value = exclusive
+		}
+	}
+
+	for index := 0; index+1 < len(schema.Content); index += 2 {
+		key, value := schema.Content[index].Value, schema.Content[index+1]
+
+		switch key {
+		case "properties":
+			for property := 1; property < len(value.Content); property += 2 {
+				convertSchemaToOpenApi31(value.Content[property])
+			}
+
+		case "items", "additionalProperties", "not":
+			convertSchemaToOpenApi31(value)
+
+		case "oneOf", "anyOf", "allOf":
+			for _, content := range value.Content {
+				convertSchemaToOpenApi31(content)
+			}
+		}
+	}
+}
+
+// Allow the `null` value in a schema, either by adding it to the types of the schema, or to its alternatives.
+func makeNullable(schema *yaml.Node) {
+	null := func() *yaml.Node { return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"} }
+	nullType := func() *yaml.Node { return mappingNode("type", stringNode("null")) }
+
+	if index := mappingIndex(schema, "type"); index >= 0 {
+		schema.Content[index+1] = sequenceNode(schema.Content[index+1], stringNode("null"))
+
+		// The enum restricts the values of the schema as well
+		if index := mappingIndex(schema, "enum"); index >= 0 {
+			schema.Content[index+1].Content = append(schema.Content[index+1].Content, null())
+		}
+	} else if reference := removeMappingValue(schema, "$ref"); reference != nil {
+		schema.Content = append(schema.Content, stringNode("anyOf"), sequenceNode(mappingNode("$ref", reference), nullType()))
+	} else if index := mappingIndex(schema, "oneOf"); index >= 0 {
+		schema.Content[index+1].Content = append(schema.Content[index+1].Content, nullType())
+	} else if index := mappingIndex(schema, "anyOf"); index >= 0 {
+		schema.Content[index+1].Content = append(schema.Content[index+1].Content, nullType())
+	}
+}
+
+// Convert the schemas of a decoded OpenAPI 3.1 document (the `schema` of the parameters, headers & media types and
+// the component schemas) back into the semantics of OpenAPI 3.0, so they can be decoded into the OpenAPI model types.
+// It is the inverse of the conversion of OpenAPI.Marshal.
+func ConvertFromOpenApi31(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, content := range node.Content {
+			ConvertFromOpenApi31(content)
+		}
+
+	case yaml.MappingNode:
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			key, value := node.Content[index].Value, node.Content[index+1]
+
+			switch key {
+			case "schema":
+				convertSchemaFromOpenApi31(value)
+
+			case "schemas":
+				for schema := 1; schema < len(value.Content); schema += 2 {
+					convertSchemaFromOpenApi31(value.Content[schema])
+				}
+
+			default:
+				ConvertFromOpenApi31(value)
+			}
+		}
+	}
+}
+
+// Convert a schema (and its sub-schemas) into the semantics of OpenAPI 3.0: the `null` type is replaced with
+// `nullable`, `examples` with `example` and the numeric exclusive bounds with boolean ones.
+func convertSchemaFromOpenApi31(schema *yaml.Node) {
+	if schema.Kind != yaml.MappingNode {
+		return
+	}
+
+	nullable := false
+
+	if index := mappingIndex(schema, "type"); index >= 0 && schema.Content[index+1].Kind == yaml.SequenceNode {
+		types := withoutNull(schema.Content[index+1].Content, func(node *yaml.Node) bool { return node.Value == "null" })
+		nullable = len(types) < len(schema.Content[index+1].Content)
+
+		if len(types) == 1 {
+			schema.Content[index+1] = types[0]
+		} else {
+			schema.Content[index+1].Content = types
+		}
+
+		if index := mappingIndex(schema, "enum"); index >= 0 {
+			schema.Content[index+1].Content = withoutNull(schema.Content[index+1].Content, func(node *yaml.Node) bool { return node.Tag == "!!null" })
+		}
+	}
+
+	for _, composition := range []string{"anyOf", "oneOf"} {
+		index := mappingIndex(schema, composition)
+		if index < 0 {
+			continue
+		}
+
+		alternatives := withoutNull(schema.Content[index+1].Content, isNullType)
+		if len(alternatives) == len(schema.Content[index+1].Content) {
+			continue
+		}
+
+		nullable = true
+		schema.Content[index+1].Content = alternatives
+
+		// A nullable reference is wrapped with `anyOf`
+		if len(alternatives) == 1 && len(alternatives[0].Content) == 2 && alternatives[0].Content[0].Value == "$ref" {
+			schema.Content = append(schema.Content[:index], schema.Content[index+2:]...)
+			schema.Content = append(schema.Content, alternatives[0].Content...)
+		}
+	}
+
+	if nullable {
+		schema.Content = append(schema.Content, stringNode("nullable"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	}
+
+	if index := mappingIndex(schema, "examples"); index >= 0 && schema.Content[index+1].Kind == yaml.SequenceNode {
+		examples := schema.Content[index+1].Content
+		if len(examples) > 0 {
+			schema.Content[index].Value = "example"
+			schema.Content[index+1] = examples[0]
+		}
+	}
+
+	for exclusive, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+		if index := mappingIndex(schema, exclusive); index >= 0 && schema.Content[index+1].Tag != "!!bool" {
+			schema.Content[index].Value = bound
+			schema.Content = append(schema.Content, stringNode(exclusive), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+		}
+	}
+
+	for index := 0; index+1 < len(schema.Content); index += 2 {
+		key, value := schema.Content[index].Value, schema.Content[index+1]
+
+		switch key {
+		case "properties":
+			for property := 1; property < len(value.Content); property += 2 {
+				convertSchemaFromOpenApi31(value.Content[property])
+			}
+
+		case "items", "additionalProperties", "not":
+			convertSchemaFromOpenApi31(value)
+
+		case "oneOf", "anyOf", "allOf":
+			for _, content := range value.Content {
+				convertSchemaFromOpenApi31(content)
+			}
+		}
+	}
+}
+
+// Whether a schema is the `{type: null}` alternative of a nullable composition.
+func isNullType(schema *yaml.Node) bool {
+	return schema.Kind == yaml.MappingNode && len(schema.Content) == 2 && schema.Content[0].Value == "type" && schema.Content[1].Value == "null"
+}
+
+func withoutNull(nodes []*yaml.Node, isNull func(*yaml.Node) bool) []*yaml.Node {
+	filtered := []*yaml.Node{}
+	for _, node := range nodes {
+		if !isNull(node) {
+			filtered = append(filtered, node)
+		}
+	}
+
+	return filtered
+}
+
+// Returns the index of the key in a mapping node, or -1 if the mapping doesn't contain the key.
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		if mapping.Content[index].Value == key {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Remove a key from a mapping node and returns its value, or nil if the mapping doesn't contain the key.
+func removeMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	index := mappingIndex(mapping, key)
+	if index < 0 {
+		return nil
+	}
+
+	value := mapping.Content[index+1]
+	mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+	return value
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func sequenceNode(content ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: content}
+}
+
+func mappingNode(key string, value *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{stringNode(key), value}}
+}
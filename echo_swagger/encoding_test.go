@@ -0,0 +1,404 @@
+package echo_swagger
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// Regenerate the golden files with `go test ./echo_swagger -run TestGoldenFiles -update`
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files")
+
+func TestFormatFromPath(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := map[string]Format{
+		"openapi.json":  FormatJson,
+		"openapi.JSON":  FormatJson,
+		"openapi.yaml":  FormatYaml,
+		"openapi.yml":   FormatYaml,
+		"/dev/stdout":   FormatYaml,
+		"api.json.yaml": FormatYaml,
+	}
+
+	for path, expected := range testCases {
+		assert.Equal(expected, FormatFromPath(path), path)
+	}
+}
+
+func TestParseOpenApiVersion(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := map[string]string{
+		"3.0":   OpenApiVersion,
+		"3.0.0": OpenApiVersion,
+		"3.1":   OpenApiVersion31,
+		"3.1.0": OpenApiVersion31,
+	}
+
+	for version, expected := range testCases {
+		actual, err := ParseOpenApiVersion(version)
+		assert.NoError(err, version)
+		assert.Equal(expected, actual, version)
+	}
+
+	_, err := ParseOpenApiVersion("2.0")
+	assert.Equal(InvalidOpenApiVersionError{Version: "2.0"}, err)
+}
+
+func TestMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	minimum := 1.0
+
+	openapi := func(version string) OpenAPI {
+		return OpenAPI{
+			OpenAPI: version,
+			Info:    Info{Title: "Example", Version: "1.0"},
+			Paths: map[string]*Path{
+				"/users": {
+					Get: &Operation{
+						Responses: map[string]Response{
+							"200": {
+								Description: "The <b>users</b>",
+								Content: map[string]MediaType{
+									ContentTypeJson: {
+										Schema: Schema{
+											Property: Property{
+												Type: PropertyType_Object,
+												Properties: map[string]Property{
+													"age":    {Type: PropertyType_Integer, Minimum: &minimum, ExclusiveMinimum: true, Example: 21},
+													"status": {Type: PropertyType_String, Nullable: true, Enum: []interface{}{"active"}},
+													"owner":  {Reference: ComponentSchemasPrefix + "valid.User", Nullable: true},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	type testCase struct {
+		name          string
+		openapi       OpenAPI
+		format        Format
+		expectedError error
+		expected      string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "yaml",
+			openapi: openapi(OpenApiVersion),
+			format:  FormatYaml,
+			expected: `openapi: 3.0.0
+info:
+    title: Example
+    version: "1.0"
+paths:
+    /users:
+        get:
+            responses:
+                "200":
+                    description: The <b>users</b>
+                    content:
+                        application/json:
+                            schema:
+                                type: object
+                                properties:
+                                    age:
+                                        type: integer
+                                        example: 21
+                                        minimum: 1
+                                        exclusiveMinimum: true
+                                    owner:
+                                        $ref: '#/components/schemas/valid.User'
+                                        nullable: true
+                                    status:
+                                        type: string
+                                        nullable: true
+                                        enum:
+                                            - active
+`,
+		},
+		{
+			name:    "json",
+			openapi: openapi(OpenApiVersion),
+			format:  FormatJson,
+			expected: `{
+  "openapi": "3.0.0",
+  "info": {
+    "title": "Example",
+    "version": "1.0"
+  },
+  "paths": {
+    "/users": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "The <b>users</b>",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "age": {
+                      "type": "integer",
+                      "example": 21,
+                      "minimum": 1,
+                      "exclusiveMinimum": true
+                    },
+                    "owner": {
+                      "$ref": "#/components/schemas/valid.User",
+                      "nullable": true
+                    },
+                    "status": {
+                      "type": "string",
+                      "nullable": true,
+                      "enum": [
+                        "active"
+                      ]
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`,
+		},
+		{
+			name:    "openapi 3.1",
+			openapi: openapi(OpenApiVersion31),
+			format:  FormatJson,
+			expected: `{
+  "openapi": "3.1.0",
+  "info": {
+    "title": "Example",
+    "version": "1.0"
+  },
+  "jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+  "paths": {
+    "/users": {
+      "get": {
+        "responses": {
+          "200": {
+            "description": "The <b>users</b>",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "age": {
+                      "type": "integer",
+                      "examples": [
+                        21
+                      ],
+                      "exclusiveMinimum": 1
+                    },
+                    "owner": {
+                      "anyOf": [
+                        {
+                          "$ref": "#/components/schemas/valid.User"
+                        },
+                        {
+                          "type": "null"
+                        }
+                      ]
+                    },
+                    "status": {
+                      "type": [
+                        "string",
+                        "null"
+                      ],
+                      "enum": [
+                        "active",
+                        null
+                      ]
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`,
+		},
+		{
+			name: "webhooks",
+			openapi: OpenAPI{
+				OpenAPI:  OpenApiVersion31,
+				Info:     Info{Title: "Example", Version: "1.0"},
+				Webhooks: map[string]*Path{"userCreated": {Post: &Operation{Responses: map[string]Response{"200": {Description: "OK"}}}}},
+			},
+			format: FormatYaml,
+			expected: `openapi: 3.1.0
+info:
+    title: Example
+    version: "1.0"
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+webhooks:
+    userCreated:
+        post:
+            responses:
+                "200":
+                    description: OK
+`,
+		},
+		{
+			name: "webhooks with openapi 3.0",
+			openapi: OpenAPI{
+				OpenAPI:  OpenApiVersion,
+				Info:     Info{Title: "Example", Version: "1.0"},
+				Webhooks: map[string]*Path{"userCreated": {Post: &Operation{}}},
+			},
+			format: FormatYaml,
+			expected: `openapi: 3.0.0
+info:
+    title: Example
+    version: "1.0"
+`,
+		},
+		{
+			name:          "unknown format",
+			openapi:       openapi(OpenApiVersion),
+			format:        "toml",
+			expectedError: InvalidFormatError{Format: "toml"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := testCase.openapi.Marshal(testCase.format)
+		assert.Equal(testCase.expectedError, err, testCase.name)
+		assert.Equal(testCase.expected, string(data), testCase.name)
+	}
+}
+
+func TestConvertFromOpenApi31(t *testing.T) {
+	assert := assert.New(t)
+
+	minimum, maximum := 1.0, 10.0
+
+	openapi := OpenAPI{
+		OpenAPI:           OpenApiVersion31,
+		Info:              Info{Title: "Example", Version: "1.0"},
+		JsonSchemaDialect: JsonSchemaDialect31,
+		Components: Components{
+			Schemas: map[string]Schema{
+				"valid.User": {Property: Property{
+					Type: PropertyType_Object,
+					Properties: map[string]Property{
+						"age":     {Type: PropertyType_Integer, Minimum: &minimum, ExclusiveMinimum: true, Maximum: &maximum, Nullable: true},
+						"role":    {Type: PropertyType_String, Enum: []interface{}{"admin"}, Nullable: true, Example: "admin"},
+						"manager": {Reference: ComponentSchemasPrefix + "valid.User", Nullable: true},
+						"tags":    {Type: PropertyType_Array, Items: Property{Type: PropertyType_String, Nullable: true}},
+					},
+				}},
+			},
+		},
+	}
+
+	for _, format := range []Format{FormatYaml, FormatJson} {
+		data, err := openapi.Marshal(format)
+		if !assert.NoError(err, format) {
+			continue
+		}
+
+		node := &yaml.Node{}
+		if !assert.NoError(yaml.Unmarshal(data, node), format) {
+			continue
+		}
+
+		ConvertFromOpenApi31(node)
+
+		converted := OpenAPI{}
+		if !assert.NoError(node.Decode(&converted), format) {
+			continue
+		}
+
+		// The items are decoded as maps, so the documents are compared semantically by their OpenAPI 3.0 encoding
+		expected, actual := openapi, converted
+		expected.OpenAPI, actual.OpenAPI = OpenApiVersion, OpenApiVersion
+
+		expectedData, err := expected.Marshal(FormatYaml)
+		assert.NoError(err, format)
+		actualData, err := actual.Marshal(FormatYaml)
+		assert.NoError(err, format)
+
+		differences, err := CompareDocuments(expectedData, actualData)
+		assert.NoError(err, format)
+		assert.Empty(differences, format)
+	}
+}
+
+func TestGoldenFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	type goldenFile struct {
+		version string
+		format  Format
+		path    string
+	}
+
+	goldenFiles := []goldenFile{
+		{version: OpenApiVersion, format: FormatYaml, path: "../testdata/golden/valid-3.0.yaml"},
+		{version: OpenApiVersion, format: FormatJson, path: "../testdata/golden/valid-3.0.json"},
+		{version: OpenApiVersion31, format: FormatYaml, path: "../testdata/golden/valid-3.1.yaml"},
+		{version: OpenApiVersion31, format: FormatJson, path: "../testdata/golden/valid-3.1.json"},
+	}
+
+	outputs := make([][]string, len(goldenFiles))
+
+	// Every run ranges over the maps in a different order, so the output must not depend on it
+	for run := 0; run < 3; run++ {
+		context := NewContext()
+
+		if !assert.NoError(context.ApplyConfig(generalConfig())) || !assert.NoError(context.ParseDirectory("../testdata/valid", "./...")) {
+			return
+		}
+
+		for index, goldenFile := range goldenFiles {
+			openapi := context.OpenAPI
+			openapi.OpenAPI = goldenFile.version
+
+			data, err := openapi.Marshal(goldenFile.format)
+			if !assert.NoError(err, goldenFile.path) {
+				return
+			}
+
+			outputs[index] = append(outputs[index], string(data))
+		}
+	}
+
+	for index, goldenFile := range goldenFiles {
+		for _, output := range outputs[index][1:] {
+			assert.Equal(outputs[index][0], output, goldenFile.path)
+		}
+
+		if *updateGoldenFiles {
+			assert.NoError(os.WriteFile(goldenFile.path, []byte(outputs[index][0]), 0o644))
+			continue
+		}
+
+		expected, err := os.ReadFile(goldenFile.path)
+		if assert.NoError(err, goldenFile.path) {
+			assert.Equal(string(expected), outputs[index][0], goldenFile.path)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package echo_swagger
 
 import (
 	"fmt"
+	"strings"
 )
 
 type AttributeError struct {
@@ -36,6 +37,28 @@ func (e MissingAttributeError) Error() string {
 	return fmt.Sprintf("missing attribute `%s`", e.AttributeName)
 }
 
+// An error that returned whenever the variables of a handler route don't match the parameters of its `Path` section
+type PathParametersMismatchError struct {
+	Handler  string
+	Route    string
+	Missing  []string
+	Extra    []string
+	Position string
+}
+
+func (e PathParametersMismatchError) Error() string {
+	reasons := []string{}
+	if len(e.Missing) > 0 {
+		reasons = append(reasons, fmt.Sprintf("variables without path parameters: `%s`", strings.Join(e.Missing, "`, `")))
+	}
+
+	if len(e.Extra) > 0 {
+		reasons = append(reasons, fmt.Sprintf("path parameters without variables: `%s`", strings.Join(e.Extra, "`, `")))
+	}
+
+	return fmt.Sprintf("%s: handler `%s` doesn't match its route `%s`, %s", e.Position, e.Handler, e.Route, strings.Join(reasons, ", "))
+}
+
 // An error that returned whenever there is a two handlers (or more) with the exactly same path and method
 type DuplicateMethodError struct {
 	Method string
@@ -97,6 +120,91 @@ func (e DuplicateResponseError) Error() string {
 	return fmt.Sprintf("duplicate response `%s`", e.StatusCode)
 }
 
+// An error that returned whenever two structures declare shared responses with the same component name.
+type DuplicateSharedResponseError struct {
+	Name              string
+	Structure         string
+	ExistingStructure string
+}
+
+func (e DuplicateSharedResponseError) Error() string {
+	return fmt.Sprintf("shared response `%s` of `%s` is already declared by `%s`", e.Name, e.Structure, e.ExistingStructure)
+}
+
+// An error that returned whenever a response has a status code that is not a valid HTTP status code, a range (e.g. `4XX`) or `default`
+type InvalidStatusCodeError struct {
+	StatusCode string
+}
+
+func (e InvalidStatusCodeError) Error() string {
+	return fmt.Sprintf("invalid status code `%s`", e.StatusCode)
+}
+
+// An error that returned whenever an operation doesn't declare any response and there is no default response
+type MissingResponsesError struct {
+	Route  string
+	Method string
+}
+
+func (e MissingResponsesError) Error() string {
+	return fmt.Sprintf("operation `%s %s` has no responses, declare a response or a default response", e.Method, e.Route)
+}
+
+// An error that returned whenever a security scheme is missing fields that are required by its type
+type InvalidSecuritySchemeError struct {
+	Reason string
+}
+
+func (e InvalidSecuritySchemeError) Error() string {
+	return fmt.Sprintf("invalid security scheme: %s", e.Reason)
+}
+
+// An error that returned whenever a handler requires a security scheme that was not declared
+type UnknownSecuritySchemeError struct {
+	Name string
+}
+
+func (e UnknownSecuritySchemeError) Error() string {
+	return fmt.Sprintf("unknown security scheme `%s`", e.Name)
+}
+
+// An error that returned whenever the handlers are detected by an unknown handler detection
+type InvalidHandlerDetectionError struct {
+	Detection string
+}
+
+func (e InvalidHandlerDetectionError) Error() string {
+	return fmt.Sprintf("invalid handler detection `%s`, expected `%s` or `%s`", e.Detection, HandlerDetectionSuffix, HandlerDetectionInterface)
+}
+
+// An error that returned whenever the interface of the request handlers could not be resolved
+type HandlerInterfaceNotFoundError struct {
+	Name   string
+	Reason string
+}
+
+func (e HandlerInterfaceNotFoundError) Error() string {
+	return fmt.Sprintf("handler interface `%s` not found: %s", e.Name, e.Reason)
+}
+
+// An error that returned whenever the specifications are written in an unknown format
+type InvalidFormatError struct {
+	Format string
+}
+
+func (e InvalidFormatError) Error() string {
+	return fmt.Sprintf("invalid format `%s`, expected `%s` or `%s`", e.Format, FormatYaml, FormatJson)
+}
+
+// An error that returned whenever the specifications are emitted in an unsupported OpenAPI version
+type InvalidOpenApiVersionError struct {
+	Version string
+}
+
+func (e InvalidOpenApiVersionError) Error() string {
+	return fmt.Sprintf("invalid OpenAPI version `%s`, expected `3.0` or `3.1`", e.Version)
+}
+
 func wrapError(err error, message string, args ...interface{}) error {
 	return fmt.Errorf(message+": %w", append(args, err)...)
 }
@@ -1,31 +1,131 @@
 package echo_swagger
 
-// This interface is used to implement the pattern for echo request handles.
-// The echo swagger generator will use this interface to determine if a function
-// is a echo request handler and will use to generate for it the swagger.
+import (
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// The way the request handlers are detected among the structures of the parsed packages.
+type HandlerDetection string
+
+const (
+	// Structures that their name ends with `Request` are request handlers (the default).
+	HandlerDetectionSuffix HandlerDetection = "suffix"
+
+	// Structures that implement the handler interface are request handlers, see Context.HandlerInterface.
+	HandlerDetectionInterface HandlerDetection = "interface"
+)
+
+// Validate that the detection is one of the known handler detections.
+func (detection HandlerDetection) Validate() error {
+	switch detection {
+	case "", HandlerDetectionSuffix, HandlerDetectionInterface:
+		return nil
+	}
+
+	return InvalidHandlerDetectionError{Detection: string(detection)}
+}
+
+// Resolve the interface that the request handlers implement from the loaded packages (and their imports).
+// The interface is looked up by its fully qualified name (e.g. `example.com/api.Handler`), or built as
+// the `RequestHandler` interface whenever no interface is configured.
+func (context *Context) resolveHandlerInterface(pkgs []*packages.Package) (*types.Interface, error) {
+	if context.HandlerInterface == "" {
+		for path := range echoPackages {
+			if pkg := findPackage(pkgs, path); pkg != nil {
+				if obj, ok := pkg.Scope().Lookup("Context").(*types.TypeName); ok {
+					return requestHandlerInterface(obj.Type()), nil
+				}
+			}
+		}
+
+		return nil, HandlerInterfaceNotFoundError{Name: "RequestHandler", Reason: "echo is not imported by the parsed packages"}
+	}
+
+	separator := strings.LastIndex(context.HandlerInterface, ".")
+	if separator <= 0 {
+		return nil, HandlerInterfaceNotFoundError{Name: context.HandlerInterface, Reason: "expected a fully qualified name, e.g. `example.com/api.Handler`"}
+	}
+
+	path, name := context.HandlerInterface[:separator], context.HandlerInterface[separator+1:]
+
+	pkg := findPackage(pkgs, path)
+	if pkg == nil {
+		return nil, HandlerInterfaceNotFoundError{Name: context.HandlerInterface, Reason: "package `" + path + "` is not loaded"}
+	}
+
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, HandlerInterfaceNotFoundError{Name: context.HandlerInterface, Reason: "type `" + name + "` not found"}
+	}
+
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, HandlerInterfaceNotFoundError{Name: context.HandlerInterface, Reason: "type `" + name + "` is not an interface"}
+	}
+
+	return iface, nil
+}
+
+// Returns the interface that is used to implement the pattern for echo request handlers:
 //
-// Also, the echo swagger searches for a `Response` struct under the main structure,
-// and it uses it to generate the specific swagger response.
-// type RequestHandler interface {
-// 	Handle(c echo.Context) error
-// }
-
-// Check whether a function implements our RequestHandler interface.
-// func isRequestHandler(fn *ast.FuncDecl) bool {
-// 	if fn.Recv == nil || fn.Name.Name != "Handle" {
-// 		// Not a receiver method or not named "Handle"
-// 		return false
-// 	} else if fn.Type.Params == nil || len(fn.Type.Params.List) != 1 {
-// 		// There is not exactly one parameter
-// 		return false
-// 	} else if fn.Type.Results == nil || len(fn.Type.Results.List) != 1 {
-// 		// There is not exactly one return value
-// 		return false
-// 	}
-
-// 	// Check the param type and the return type
-// 	paramType := fmt.Sprintf("%v", fn.Type.Params.List[0].Type)
-// 	returnType := fmt.Sprintf("%v", fn.Type.Results.List[0].Type)
-
-// 	return paramType == "&{echo Context}" && returnType == "error"
-// }
+//	type RequestHandler interface {
+//		Handle(c echo.Context) error
+//	}
+func requestHandlerInterface(echoContext types.Type) *types.Interface {
+	params := types.NewTuple(types.NewVar(token.NoPos, nil, "c", echoContext))
+	results := types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type()))
+	handle := types.NewFunc(token.NoPos, nil, "Handle", types.NewSignatureType(nil, nil, nil, params, results, false))
+
+	return types.NewInterfaceType([]*types.Func{handle}, nil).Complete()
+}
+
+// Find a package by its path among the loaded packages and their (transitive) imports.
+func findPackage(pkgs []*packages.Package, path string) *types.Package {
+	visited := map[*types.Package]bool{}
+	queue := []*types.Package{}
+
+	for _, pkg := range pkgs {
+		if pkg.Types != nil {
+			queue = append(queue, pkg.Types)
+		}
+	}
+
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+
+		if visited[pkg] {
+			continue
+		} else if pkg.Path() == path {
+			return pkg
+		}
+
+		visited[pkg] = true
+		queue = append(queue, pkg.Imports()...)
+	}
+
+	return nil
+}
+
+// Check whether a named structure is a request handler, according to the handler detection of the context.
+func (context *Context) isRequestHandler(named *types.Named) bool {
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	if context.HandlerDetection != HandlerDetectionInterface {
+		return strings.HasSuffix(strings.ToLower(named.Obj().Name()), "request")
+	}
+
+	if context.handlerInterface == nil || named.TypeParams().Len() > 0 {
+		// The implementation of generic types can't be checked before they are instantiated
+		return false
+	}
+
+	// The methods of the handlers are usually declared on their pointers
+	return types.Implements(named, context.handlerInterface) || types.Implements(types.NewPointer(named), context.handlerInterface)
+}
@@ -0,0 +1,80 @@
+package echo_swagger
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerDetection(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCase struct {
+		name          string
+		detection     HandlerDetection
+		iface         string
+		expectedError error
+		routes        []string
+	}
+
+	testCases := []testCase{
+		{
+			name:   "default",
+			routes: []string{"/legacy"},
+		},
+		{
+			name:      "suffix",
+			detection: HandlerDetectionSuffix,
+			routes:    []string{"/legacy"},
+		},
+		{
+			name:      "request handler interface",
+			detection: HandlerDetectionInterface,
+			routes:    []string{"/profile"},
+		},
+		{
+			name:      "custom interface",
+			detection: HandlerDetectionInterface,
+			iface:     "example.com/handlers.Job",
+			routes:    []string{"/cleanup"},
+		},
+		{
+			name:          "unknown interface",
+			detection:     HandlerDetectionInterface,
+			iface:         "example.com/handlers.Worker",
+			expectedError: HandlerInterfaceNotFoundError{Name: "example.com/handlers.Worker", Reason: "type `Worker` not found"},
+		},
+		{
+			name:          "not an interface",
+			detection:     HandlerDetectionInterface,
+			iface:         "example.com/handlers.Profile",
+			expectedError: HandlerInterfaceNotFoundError{Name: "example.com/handlers.Profile", Reason: "type `Profile` is not an interface"},
+		},
+		{
+			name:          "unknown detection",
+			detection:     "name",
+			expectedError: InvalidHandlerDetectionError{Detection: "name"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		context := NewContext()
+		context.HandlerDetection = testCase.detection
+		context.HandlerInterface = testCase.iface
+
+		err := context.ParseDirectory("../testdata/handlers", "./...")
+		assert.Equal(testCase.expectedError, err, testCase.name)
+		if err != nil {
+			continue
+		}
+
+		routes := []string{}
+		for route := range context.OpenAPI.Paths {
+			routes = append(routes, route)
+		}
+
+		sort.Strings(routes)
+		assert.Equal(testCase.routes, routes, testCase.name)
+	}
+}
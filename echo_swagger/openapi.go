@@ -1,146 +1,156 @@
 package echo_swagger
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/types"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/structtag"
+	"gopkg.in/yaml.v3"
 )
 
 // This is the root document object of the OpenAPI document.
 type OpenAPI struct {
 	// REQUIRED. This string MUST be the semantic version number of the OpenAPI Specification version that the OpenAPI document uses. The openapi field SHOULD be used by tooling specifications and clients to interpret the OpenAPI document. This is not related to the API info.version string.
-	OpenAPI string `yaml:"openapi,omitempty" validate:"required"`
+	OpenAPI string `yaml:"openapi,omitempty" json:"openapi,omitempty" validate:"required"`
 
 	// REQUIRED. Provides metadata about the API. The metadata MAY be used by tooling as required.
-	Info Info `yaml:"info,omitempty" validate:"required"`
+	Info Info `yaml:"info,omitempty" json:"info,omitempty" validate:"required"`
+
+	// The default value for the $schema keyword within Schema Objects contained within this OAS document (OpenAPI 3.1 only).
+	JsonSchemaDialect string `yaml:"jsonSchemaDialect,omitempty" json:"jsonSchemaDialect,omitempty"`
 
 	// An array of Server Objects, which provide connectivity information to a target server. If the servers property is not provided, or is an empty array, the default value would be a Server Object with a url value of /.
-	Servers []Server `yaml:"servers,omitempty"`
+	Servers []Server `yaml:"servers,omitempty" json:"servers,omitempty"`
 
 	// REQUIRED. The available paths and operations for the API.
-	Paths map[string]*Path `yaml:"paths,omitempty" validate:"required"`
+	Paths map[string]*Path `yaml:"paths,omitempty" json:"paths,omitempty" validate:"required"`
+
+	// The incoming webhooks that MAY be received as part of this API and that the API consumer MAY choose to implement (OpenAPI 3.1 only). The key is a unique name of the webhook.
+	Webhooks map[string]*Path `yaml:"webhooks,omitempty" json:"webhooks,omitempty"`
 
 	// An element to hold various schemas for the specification.
-	Components Components `yaml:"components,omitempty"`
+	Components Components `yaml:"components,omitempty" json:"components,omitempty"`
 
 	// A declaration of which security mechanisms can be used across the API. The list of values includes alternative security requirement objects that can be used. Only one of the security requirement objects need to be satisfied to authorize a request. Individual operations can override this definition. To make security optional, an empty security requirement ({}) can be included in the array.
-	Security []SecurityRequirement `yaml:"security,omitempty"`
+	Security []SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
 
 	// A list of tags used by the specification with additional metadata. The order of the tags can be used to reflect on their order by the parsing tools. Not all tags that are used by the Operation Object must be declared. The tags that are not declared MAY be organized randomly or based on the tools' logic. Each tag name in the list MUST be unique.
-	Tags []Tag `yaml:"tags,omitempty"`
+	Tags []Tag `yaml:"tags,omitempty" json:"tags,omitempty"`
 
 	// Additional external documentation.
-	ExternalDocs ExternalDocumentation `yaml:"externalDocs,omitempty"`
+	ExternalDocs ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
 }
 
 // The object provides metadata about the API. The metadata MAY be used by the clients if needed, and MAY be presented in editing or documentation generation tools for convenience.
 type Info struct {
 	// REQUIRED. The title of the API.
-	Title string `yaml:"title,omitempty" validate:"required"`
+	Title string `yaml:"title,omitempty" json:"title,omitempty" validate:"required"`
 
 	// A short description of the API. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// A URL to the Terms of Service for the API. MUST be in the format of a URL.
-	TermsOfService string `yaml:"termsOfService,omitempty"`
+	TermsOfService string `yaml:"termsOfService,omitempty" json:"termsOfService,omitempty"`
 
 	// The contact information for the exposed API.
-	Contact InfoContact `yaml:"contact,omitempty"`
+	Contact InfoContact `yaml:"contact,omitempty" json:"contact,omitempty"`
 
 	// The license information for the exposed API.
-	License InfoLicense `yaml:"license,omitempty"`
+	License InfoLicense `yaml:"license,omitempty" json:"license,omitempty"`
 
 	// REQUIRED. The version of the OpenAPI document (which is distinct from the OpenAPI Specification version or the API implementation version).
-	Version string `yaml:"version,omitempty"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
 }
 
 // Contact information for the exposed API.
 type InfoContact struct {
 	// The identifying name of the contact person/organization.
-	Name string `yaml:"name,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
 
 	// The URL pointing to the contact information. MUST be in the format of a URL.
-	URL string `yaml:"url,omitempty"`
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
 
 	// The email address of the contact person/organization. MUST be in the format of an email address.
-	Email string `yaml:"email,omitempty"`
+	Email string `yaml:"email,omitempty" json:"email,omitempty"`
 }
 
 // License information for the exposed API.
 type InfoLicense struct {
 	// REQUIRED. The license name used for the API.
-	Name string `yaml:"name,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
 
 	// A URL to the license used for the API. MUST be in the format of a URL.
-	URL string `yaml:"url,omitempty"`
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
 }
 
 // An object representing a Server.
 type Server struct {
 	// REQUIRED. A URL to the target host. This URL supports Server Variables and MAY be relative, to indicate that the host location is relative to the location where the OpenAPI document is being served. Variable substitutions will be made when a variable is named in {brackets}.
-	URL string `yaml:"url,omitempty" validate:"required"`
+	URL string `yaml:"url,omitempty" json:"url,omitempty" validate:"required"`
 
 	// An optional string describing the host designated by the URL. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// A map between a variable name and its value. The value is used for substitution in the server's URL template.
-	Variables map[string]ServerVariable `yaml:"variables,omitempty"`
+	Variables map[string]ServerVariable `yaml:"variables,omitempty" json:"variables,omitempty"`
 }
 
 // An object representing a Server Variable for server URL template substitution.
 type ServerVariable struct {
 	// An enumeration of string values to be used if the substitution options are from a limited set. The array SHOULD NOT be empty.
-	Enum []string `yaml:"enum,omitempty"`
+	Enum []string `yaml:"enum,omitempty" json:"enum,omitempty"`
 
 	// REQUIRED. The default value to use for substitution, which SHALL be sent if an alternate value is not supplied. Note this behavior is different than the Schema Object's treatment of default values, because in those cases parameter values are optional. If the enum is defined, the value SHOULD exist in the enum's values.
-	Default string `yaml:"default,omitempty" validate:"required"`
+	Default string `yaml:"default,omitempty" json:"default,omitempty" validate:"required"`
 
 	// An optional description for the server variable. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 }
 
 // Describes the operations available on a single path. A Path Item MAY be empty, due to ACL constraints. The path itself is still exposed to the documentation viewer but they will not know which operations and parameters are available.
 type Path struct {
 	// Allows for an external definition of this path item. The referenced structure MUST be in the format of a Path Item Object. In case a Path Item Object field appears both in the defined object and the referenced object, the behavior is undefined.
-	Reference string `yaml:"$ref,omitempty"`
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty"`
 
 	// An optional, string summary, intended to apply to all operations in this path.
-	Summary string `yaml:"summary,omitempty"`
+	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
 
 	// An optional, string description, intended to apply to all operations in this path. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// A definition of a GET operation on this path.
-	Get *Operation `yaml:"get,omitempty"`
+	Get *Operation `yaml:"get,omitempty" json:"get,omitempty"`
 
 	// A definition of a PUT operation on this path.
-	Put *Operation `yaml:"put,omitempty"`
+	Put *Operation `yaml:"put,omitempty" json:"put,omitempty"`
 
 	// A definition of a POST operation on this path.
-	Post *Operation `yaml:"post,omitempty"`
+	Post *Operation `yaml:"post,omitempty" json:"post,omitempty"`
 
 	// A definition of a DELETE operation on this path.
-	Delete *Operation `yaml:"delete,omitempty"`
+	Delete *Operation `yaml:"delete,omitempty" json:"delete,omitempty"`
 
 	// A definition of a OPTIONS operation on this path.
-	Options *Operation `yaml:"options,omitempty"`
+	Options *Operation `yaml:"options,omitempty" json:"options,omitempty"`
 
 	// A definition of a HEAD operation on this path.
-	Head *Operation `yaml:"head,omitempty"`
+	Head *Operation `yaml:"head,omitempty" json:"head,omitempty"`
 
 	// A definition of a PATCH operation on this path.
-	Patch *Operation `yaml:"patch,omitempty"`
+	Patch *Operation `yaml:"patch,omitempty" json:"patch,omitempty"`
 
 	// A definition of a TRACE operation on this path.
-	Trace *Operation `yaml:"trace,omitempty"`
+	Trace *Operation `yaml:"trace,omitempty" json:"trace,omitempty"`
 
 	// An alternative server array to service all operations in this path.
-	Servers []Server `yaml:"servers,omitempty"`
+	Servers []Server `yaml:"servers,omitempty" json:"servers,omitempty"`
 
 	// A list of parameters that are applicable for all the operations described under this path. These parameters can be overridden at the operation level, but cannot be removed there. The list MUST NOT include duplicated parameters. A unique parameter is defined by a combination of a name and location. The list can use the Reference Object to link to parameters that are defined at the OpenAPI Object's components/parameters.
-	Parameters []Parameter `yaml:"parameters,omitempty"`
+	Parameters []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 }
 
 func (p *Path) GetOperationByMethod(method string) (*Operation, error) {
@@ -220,43 +230,60 @@ func (p *Path) SetOperationByMethod(method string, operation *Operation) error {
 	}
 }
 
+// The methods of the operations of a path, by the order they are declared in the path.
+var Methods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+// Returns the operations of the path by the order of their methods.
+func (p *Path) Operations() []*Operation {
+	operations := []*Operation{}
+
+	for _, method := range Methods {
+		if operation, _ := p.GetOperationByMethod(method); operation != nil {
+			operations = append(operations, operation)
+		}
+	}
+
+	return operations
+}
+
 // Describes a single API operation on a path.
 type Operation struct {
 	// A list of tags for API documentation control. Tags can be used for logical grouping of operations by resources or any other qualifier.
-	Tags []string `yaml:"tags,omitempty"`
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
 
 	// A short summary of what the operation does.
-	Summary string `yaml:"summary,omitempty"`
+	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
 
 	// A verbose explanation of the operation behavior. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// Additional external documentation for this operation.
-	ExternalDocs ExternalDocumentation `yaml:"externalDocs,omitempty"`
+	ExternalDocs ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
 
 	// Unique string used to identify the operation. The id MUST be unique among all operations described in the API. The operationId value is case-sensitive. Tools and libraries MAY use the operationId to uniquely identify an operation, therefore, it is RECOMMENDED to follow common programming naming conventions.
-	OperationId string `yaml:"operationId,omitempty"`
+	OperationId string `yaml:"operationId,omitempty" json:"operationId,omitempty"`
 
 	// A list of parameters that are applicable for this operation. If a parameter is already defined at the Path Item, the new definition will override it but can never remove it. The list MUST NOT include duplicated parameters. A unique parameter is defined by a combination of a name and location. The list can use the Reference Object to link to parameters that are defined at the OpenAPI Object's components/parameters.
-	Parameters []Parameter `yaml:"parameters,omitempty"`
+	Parameters []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 
 	// The request body applicable for this operation. The requestBody is only supported in HTTP methods where the HTTP 1.1 specification RFC7231 has explicitly defined semantics for request bodies. In other cases where the HTTP spec is vague, requestBody SHALL be ignored by consumers.
-	RequestBody RequestBody `yaml:"requestBody,omitempty"`
+	RequestBody RequestBody `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
 
 	// REQUIRED. The list of possible responses as they are returned from executing this operation.
-	Responses map[string]Response `yaml:"responses"`
+	Responses map[string]Response `yaml:"responses" json:"responses"`
 
 	// A map of possible out-of band callbacks related to the parent operation. The key is a unique identifier for the Callback Object. Each value in the map is a Callback Object that describes a request that may be initiated by the API provider and the expected responses.
-	Callbacks map[string]Callback `yaml:"callbacks,omitempty"`
+	Callbacks map[string]Callback `yaml:"callbacks,omitempty" json:"callbacks,omitempty"`
 
 	// Declares this operation to be deprecated. Consumers SHOULD refrain from usage of the declared operation. Default value is false.
-	Deprecated bool `yaml:"deprecated,omitempty"`
+	Deprecated bool `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 
 	// A declaration of which security mechanisms can be used for this operation. The list of values includes alternative security requirement objects that can be used. Only one of the security requirement objects need to be satisfied to authorize a request. To make security optional, an empty security requirement ({}) can be included in the array. This definition overrides any declared top-level security. To remove a top-level security declaration, an empty array can be used.
-	Security []SecurityRequirement `yaml:"security,omitempty"`
+	// It's a pointer so an empty array (which removes the top-level security) can be told apart from a missing declaration.
+	Security *[]SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
 
 	// An alternative server array to service this operation. If an alternative server object is specified at the Path Item Object or Root level, it will be overridden by this value.
-	Servers []Server `yaml:"servers,omitempty"`
+	Servers []Server `yaml:"servers,omitempty" json:"servers,omitempty"`
 }
 
 func (operation *Operation) AddParameter(in ParameterLocation, parameter *Parameter) error {
@@ -266,6 +293,10 @@ func (operation *Operation) AddParameter(in ParameterLocation, parameter *Parame
 }
 
 func (operation *Operation) AddResponse(code string, response *Response) error {
+	if err := validateStatusCode(code); err != nil {
+		return err
+	}
+
 	if operation.Responses == nil {
 		operation.Responses = make(map[string]Response)
 	} else if _, ok := operation.Responses[code]; ok {
@@ -285,6 +316,26 @@ const (
 	ParameterLocationPath   ParameterLocation = "path"
 )
 
+// The order of the parameters by their locations, the parameters of the same location keep the order of their declaration
+var ParameterLocations = []ParameterLocation{ParameterLocationPath, ParameterLocationQuery, ParameterLocationHeader, ParameterLocationCookie}
+
+// Sort the parameters of the operation by their locations (see ParameterLocations), and then by the order of their declaration.
+func (operation *Operation) SortParameters() {
+	rank := func(location ParameterLocation) int {
+		for position, current := range ParameterLocations {
+			if current == location {
+				return position
+			}
+		}
+
+		return len(ParameterLocations)
+	}
+
+	sort.SliceStable(operation.Parameters, func(i, j int) bool {
+		return rank(operation.Parameters[i].In) < rank(operation.Parameters[j].In)
+	})
+}
+
 // Describes a single operation parameter.
 // A unique parameter is defined by a combination of a name and location.
 //
@@ -299,7 +350,7 @@ const (
 // 4. cookie - Used to pass a specific cookie value to the API.
 type Parameter struct {
 	// A simple object to allow referencing other components in the specification, internally and externally.
-	Reference string `yaml:"$ref,omitempty" validate:"required_without=Name In Description Required Deprecated AllowEmptyValue"`
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty" validate:"required_without=Name In Description Required Deprecated AllowEmptyValue"`
 
 	// REQUIRED. The name of the parameter. Parameter names are case sensitive.
 	//
@@ -308,25 +359,25 @@ type Parameter struct {
 	// If in is "header" and the name field is "Accept", "Content-Type" or "Authorization", the parameter definition SHALL be ignored.
 	//
 	// For all other cases, the name corresponds to the parameter name used by the in property.
-	Name string `yaml:"name,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
 
 	// REQUIRED. The location of the parameter. Possible values are "query", "header", "path" or "cookie".
-	In ParameterLocation `yaml:"in,omitempty" validate:"oneof=query header path cookie"`
+	In ParameterLocation `yaml:"in,omitempty" json:"in,omitempty" validate:"oneof=query header path cookie"`
 
 	// A brief description of the parameter. This could contain examples of use. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// Determines whether this parameter is mandatory. If the parameter location is "path", this property is REQUIRED and its value MUST be true. Otherwise, the property MAY be included and its default value is false.
-	Required bool `yaml:"required"`
+	Required bool `yaml:"required" json:"required"`
 
 	// Specifies that a parameter is deprecated and SHOULD be transitioned out of usage. Default value is false.
-	Deprecated bool `yaml:"deprecated,omitempty"`
+	Deprecated bool `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 
 	// Sets the ability to pass empty-valued parameters. This is valid only for query parameters and allows sending a parameter with an empty value. Default value is false. If style is used, and if behavior is n/a (cannot be serialized), the value of allowEmptyValue SHALL be ignored. Use of this property is NOT RECOMMENDED, as it is likely to be removed in a later revision.
-	AllowEmptyValue bool `yaml:"allowEmptyValue,omitempty"`
+	AllowEmptyValue bool `yaml:"allowEmptyValue,omitempty" json:"allowEmptyValue,omitempty"`
 
 	// The schema defining the content of the request parameter.
-	Schema Schema `yaml:"schema,omitempty"`
+	Schema Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
 }
 
 func (parameter *Parameter) SetLocation(location ParameterLocation) {
@@ -340,40 +391,40 @@ func (parameter *Parameter) SetLocation(location ParameterLocation) {
 // Allows referencing an external resource for extended documentation.
 type ExternalDocumentation struct {
 	// A short description of the target documentation. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// REQUIRED. The URL for the target documentation. Value MUST be in the format of a URL.
-	URL string `yaml:"url,omitempty" validate:"required"`
+	URL string `yaml:"url,omitempty" json:"url,omitempty" validate:"required"`
 }
 
 // Describes a single request body.
 type RequestBody struct {
 	// A simple object to allow referencing other components in the specification, internally and externally.
-	Reference string `yaml:"$ref,omitempty" validate:"required_without=Content Description Required"`
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty" validate:"required_without=Content Description Required"`
 
 	// A brief description of the request body. This could contain examples of use. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// REQUIRED. The content of the request body. The key is a media type or media type range and the value describes it. For requests that match multiple keys, only the most specific key is applicable. e.g. text/plain overrides text/*
-	Content map[string]MediaType `yaml:"content,omitempty"`
+	Content map[string]MediaType `yaml:"content,omitempty" json:"content,omitempty"`
 
 	// Determines if the request body is required in the request. Defaults to false.
-	Required bool `yaml:"required,omitempty"`
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
 }
 
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 type MediaType struct {
 	// The schema defining the content of the request, response, or parameter.
-	Schema Schema `yaml:"schema,omitempty"`
+	Schema Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
 
 	// Example of the media type. The example object SHOULD be in the correct format as specified by the media type. The example field is mutually exclusive of the examples field. Furthermore, if referencing a schema which contains an example, the example value SHALL override the example provided by the schema./
-	Example interface{} `yaml:"example,omitempty"`
+	Example interface{} `yaml:"example,omitempty" json:"example,omitempty"`
 
 	// Examples of the media type. Each example object SHOULD match the media type and specified schema if present. The examples field is mutually exclusive of the example field. Furthermore, if referencing a schema which contains an example, the examples value SHALL override the example provided by the schema.
-	Examples map[string]Example `yaml:"examples,omitempty"`
+	Examples map[string]Example `yaml:"examples,omitempty" json:"examples,omitempty"`
 
 	// A map between a property name and its encoding information. The key, being the property name, MUST exist in the schema as a property. The encoding object SHALL only apply to requestBody objects when the media type is multipart or application/x-www-form-urlencoded.
-	Encoding map[string]Encoding `yaml:"encoding,omitempty"`
+	Encoding map[string]Encoding `yaml:"encoding,omitempty" json:"encoding,omitempty"`
 }
 
 type PropertyType string
@@ -452,34 +503,116 @@ func typeAndFormatFromKind(kind types.BasicKind) (PropertyType, PropertyFormat)
 }
 
 type Property struct {
+	// A simple object to allow referencing other components in the specification, internally and externally.
+	// When set, the rest of the property is ignored by the consumers.
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+
 	// REQUIRED. The schema defining the type used for the query or form parameter.
-	Type PropertyType `yaml:"type,omitempty" validate:"required"`
+	Type PropertyType `yaml:"type,omitempty" json:"type,omitempty" validate:"required"`
 
 	// Specifies the properties of the object if the property type is "object".
-	Properties map[string]Property `yaml:"properties,omitempty"`
+	Properties map[string]Property `yaml:"properties,omitempty" json:"properties,omitempty"`
+
+	// The names of the properties in the order of their declaration, the properties are marshaled in this order (see MarshalYAML).
+	PropertiesOrder []string `yaml:"-" json:"-"`
 
 	// Specifies the format of the type.
-	Format PropertyFormat `yaml:"format,omitempty"`
+	Format PropertyFormat `yaml:"format,omitempty" json:"format,omitempty"`
 
 	// Description about this property.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// A list of the required properties.
-	RequiredProperties []string `yaml:"required,omitempty"`
+	RequiredProperties []string `yaml:"required,omitempty" json:"required,omitempty"`
 
 	// Either the property is required or not. Used for internal use
-	Required bool `yaml:"-"`
+	Required bool `yaml:"-" json:"-"`
 
 	// Specifies the items of the array if the property type is "array".
 	// It's must be of type interface{} because Items is actually a property.
-	Items interface{} `yaml:"items,omitempty"`
+	Items interface{} `yaml:"items,omitempty" json:"items,omitempty"`
 
 	// The additionalProperties keyword specifies the type of values in the dictionary.
 	// Values can be primitives (strings, numbers or boolean values), arrays or objects.
-	AdditionalProperties interface{} `yaml:"additionalProperties,omitempty"`
+	AdditionalProperties interface{} `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
+
+	// A true value adds "null" to the allowed type specified by the type keyword, only if type is explicitly defined within the same Schema Object. Other Schema Object constraints retain their defined behavior, and therefore may disallow the use of null as a value. A false value leaves the specified or default type unmodified. The default value is false.
+	Nullable bool `yaml:"nullable,omitempty" json:"nullable,omitempty"`
+
+	// Relevant only for Schema "properties" definitions. Declares the property as "read only". This means that it MAY be sent as part of a response but SHOULD NOT be sent as part of the request. If the property is marked as readOnly being true and is in the required list, the required will take effect on the response only. A property MUST NOT be marked as both readOnly and writeOnly being true. Default value is false.
+	ReadOnly bool `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+
+	// Relevant only for Schema "properties" definitions. Declares the property as "write only". Therefore, it MAY be sent as part of a request but SHOULD NOT be sent as part of the response. If the property is marked as writeOnly being true and is in the required list, the required will take effect on the request only. A property MUST NOT be marked as both readOnly and writeOnly being true. Default value is false.
+	WriteOnly bool `yaml:"writeOnly,omitempty" json:"writeOnly,omitempty"`
+
+	// A free-form property to include an example of an instance for this schema. To represent examples that cannot be naturally represented in JSON or YAML, a string value can be used to contain the example with escaping where necessary.
+	Example interface{} `yaml:"example,omitempty" json:"example,omitempty"`
+
+	// The default value represents what would be assumed by the consumer of the input as the value of the schema if one is not provided. Unlike JSON Schema, the value MUST conform to the defined type for the Schema Object defined at the same level.
+	Default interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+
+	// Restricts the value to a fixed set of values. The values SHOULD match the type of the property.
+	Enum []interface{} `yaml:"enum,omitempty" json:"enum,omitempty"`
+
+	// An extension that holds the description of every value of the enum, by the same order of the values.
+	EnumDescriptions []string `yaml:"x-enum-descriptions,omitempty" json:"x-enum-descriptions,omitempty"`
+
+	// Specifies that a schema is deprecated and SHOULD be transitioned out of usage. Default value is false.
+	Deprecated bool `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+
+	// The inclusive lower limit of a numeric property (exclusive when ExclusiveMinimum is true).
+	Minimum *float64 `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+
+	// Whether the value of the property must be strictly greater than the minimum.
+	ExclusiveMinimum bool `yaml:"exclusiveMinimum,omitempty" json:"exclusiveMinimum,omitempty"`
+
+	// The inclusive upper limit of a numeric property (exclusive when ExclusiveMaximum is true).
+	Maximum *float64 `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+
+	// Whether the value of the property must be strictly less than the maximum.
+	ExclusiveMaximum bool `yaml:"exclusiveMaximum,omitempty" json:"exclusiveMaximum,omitempty"`
+
+	// The minimum length of a string property.
+	MinLength *uint64 `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+
+	// The maximum length of a string property.
+	MaxLength *uint64 `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+
+	// The minimum amount of items of an array property.
+	MinItems *uint64 `yaml:"minItems,omitempty" json:"minItems,omitempty"`
+
+	// The maximum amount of items of an array property.
+	MaxItems *uint64 `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
+
+	// Whether all the items of an array property must be unique.
+	UniqueItems bool `yaml:"uniqueItems,omitempty" json:"uniqueItems,omitempty"`
+
+	// The minimum amount of properties of an object property.
+	MinProperties *uint64 `yaml:"minProperties,omitempty" json:"minProperties,omitempty"`
+
+	// The maximum amount of properties of an object property.
+	MaxProperties *uint64 `yaml:"maxProperties,omitempty" json:"maxProperties,omitempty"`
+
+	// The value must be valid against exactly one of the subschemas.
+	OneOf []Property `yaml:"oneOf,omitempty" json:"oneOf,omitempty"`
+
+	// The value must be valid against any (one or more) of the subschemas.
+	AnyOf []Property `yaml:"anyOf,omitempty" json:"anyOf,omitempty"`
+
+	// The value must be valid against all of the subschemas.
+	AllOf []Property `yaml:"allOf,omitempty" json:"allOf,omitempty"`
+
+	// Adds support for polymorphism. The discriminator is an object name that is used to differentiate between other schemas which may satisfy the payload description. See Composition and Inheritance for more details.
+	Discriminator Discriminator `yaml:"discriminator,omitempty" json:"discriminator,omitempty"`
+
+	// This MAY be used only on properties schemas. It has no effect on root schemas. Adds additional metadata to describe the XML representation of this property.
+	XML *XML `yaml:"xml,omitempty" json:"xml,omitempty"`
 
 	// Used for internal use
-	Name string `yaml:"-"`
+	Name string `yaml:"-" json:"-"`
+
+	// The content type of the property when it is sent as a part of a multipart body, used for internal use
+	ContentType string `yaml:"-" json:"-"`
 }
 
 func (p Property) String() string {
@@ -496,14 +629,40 @@ func (p Property) String() string {
 		return data
 
 	default:
+		if p.Reference != "" {
+			return p.Reference
+		}
+
 		return string(p.Type)
 	}
 }
 
+// Marshal the property with its properties in the order of their declaration, instead of the order of their names.
+func (p Property) MarshalYAML() (interface{}, error) {
+	// The alias doesn't have the MarshalYAML method, so it is encoded by its fields
+	type property Property
+
+	node := &yaml.Node{}
+	if err := node.Encode(property(p)); err != nil {
+		return nil, err
+	}
+
+	OrderProperties(node, p.PropertiesOrder)
+	return node, nil
+}
+
+// Returns whether the property is only a reference to a component schema.
+func (p Property) IsReference() bool {
+	return p.Reference != ""
+}
+
 func (p Property) IgnoreProperty() bool {
 	return p.Name == "-"
 }
 
+// Applies the tags of a field to the property: its name (by the name tag), its encoding options and its validations.
+// The `omitempty` option doesn't change the schema, since it omits only empty values, which never pass the `required`
+// validation that makes the property required.
 func (p *Property) ParseTags(data string, nameTag string, fieldName string) error {
 	p.Name = fieldName
 
@@ -517,43 +676,175 @@ func (p *Property) ParseTags(data string, nameTag string, fieldName string) erro
 	}
 
 	name, err := tags.Get(nameTag)
-	if err == nil {
+	if err == nil && name.Name != "" {
 		p.Name = name.Name
 	}
 
-	required, err := tags.Get(ValidateTag)
-	if err == nil && required.Name == ValidateRequiredValue {
-		p.Required = true
+	if err == nil && nameTag == JsonTag && name.HasOption("string") {
+		// The `string` option encodes numbers and booleans inside strings
+		switch p.Type {
+		case PropertyType_Integer, PropertyType_Number, PropertyType_Boolean:
+			p.Type, p.Format = PropertyType_String, PropertyFormat_None
+		}
+	}
+
+	if err == nil && nameTag == XmlTag && name.HasOption("attr") {
+		// The field is encoded as an attribute of the element instead of a child element
+		p.XML = &XML{Attribute: true}
+	}
+
+	validate, err := tags.Get(ValidateTag)
+	if err == nil {
+		p.parseValidations(strings.Split(validate.Value(), ","))
 	}
 
 	return nil
 }
 
-// The Schema Object allows the definition of input and output data types. These types can be objects, but also primitives and arrays. This object is an extended subset of the JSON Schema Specification Wright Draft 00.
-type Schema struct {
-	// A simple object to allow referencing other components in the specification, internally and externally.
-	Reference string `yaml:"$ref,omitempty" validate:"required_without=Nullable Discriminator ReadOnly WriteOnly ExternalDocumentation Example Deprecated"`
+// Applies the documentation attributes of a field to the property.
+// The free text (or the `@description` attribute) is used as the description of the property.
+func (p *Property) ParseAttributes(attributes commentAttributes) error {
+	if attributes.HasKey(DescriptionAttribute) {
+		p.Description = attributes[DescriptionAttribute]
+	} else if text := attributes.Text(); text != "" {
+		p.Description = text
+	}
 
-	// A true value adds "null" to the allowed type specified by the type keyword, only if type is explicitly defined within the same Schema Object. Other Schema Object constraints retain their defined behavior, and therefore may disallow the use of null as a value. A false value leaves the specified or default type unmodified. The default value is false.
-	Nullable bool `yaml:"nullable,omitempty"`
+	if attributes.HasKey(FormatAttribute) {
+		p.Format = PropertyFormat(attributes[FormatAttribute])
+	}
 
-	// Adds support for polymorphism. The discriminator is an object name that is used to differentiate between other schemas which may satisfy the payload description. See Composition and Inheritance for more details.
-	Discriminator Discriminator `yaml:"discriminator,omitempty"`
+	if attributes.HasKey(ExampleAttribute) {
+		example, err := p.parseValue(attributes[ExampleAttribute])
+		if err != nil {
+			return InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: ExampleAttribute}, Value: attributes[ExampleAttribute]}
+		}
 
-	// Relevant only for Schema "properties" definitions. Declares the property as "read only". This means that it MAY be sent as part of a response but SHOULD NOT be sent as part of the request. If the property is marked as readOnly being true and is in the required list, the required will take effect on the response only. A property MUST NOT be marked as both readOnly and writeOnly being true. Default value is false.
-	ReadOnly bool `yaml:"readOnly,omitempty"`
+		p.Example = example
+	}
 
-	// Relevant only for Schema "properties" definitions. Declares the property as "write only". Therefore, it MAY be sent as part of a request but SHOULD NOT be sent as part of the response. If the property is marked as writeOnly being true and is in the required list, the required will take effect on the request only. A property MUST NOT be marked as both readOnly and writeOnly being true. Default value is false.
-	WriteOnly bool `yaml:"writeOnly,omitempty"`
+	if attributes.HasKey(DefaultAttribute) {
+		value, err := p.parseValue(attributes[DefaultAttribute])
+		if err != nil {
+			return InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: DefaultAttribute}, Value: attributes[DefaultAttribute]}
+		}
 
-	// Additional external documentation for this schema.
-	ExternalDocumentation ExternalDocumentation `yaml:"externalDocs,omitempty"`
+		p.Default = value
+	}
 
-	// A free-form property to include an example of an instance for this schema. To represent examples that cannot be naturally represented in JSON or YAML, a string value can be used to contain the example with escaping where necessary.
-	Example interface{} `yaml:"example,omitempty"`
+	if attributes.HasKey(EnumAttribute) {
+		p.Enum = nil
 
-	// Specifies that a schema is deprecated and SHOULD be transitioned out of usage. Default value is false.
-	Deprecated bool `yaml:"deprecated,omitempty"`
+		for _, item := range parseStringByQuotesAndSpaces(attributes[EnumAttribute]) {
+			value, err := p.parseValue(item)
+			if err != nil {
+				return InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: EnumAttribute}, Value: attributes[EnumAttribute]}
+			}
+
+			p.Enum = append(p.Enum, value)
+		}
+	}
+
+	if attributes.HasKey(ContentTypeAttribute) {
+		p.ContentType = attributes[ContentTypeAttribute]
+	}
+
+	p.Deprecated = p.Deprecated || attributes.HasKey(DeprecatedAttribute)
+	p.ReadOnly = p.ReadOnly || attributes.HasKey(ReadOnlyAttribute)
+	p.WriteOnly = p.WriteOnly || attributes.HasKey(WriteOnlyAttribute)
+
+	if p.ReadOnly && p.WriteOnly {
+		return InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: WriteOnlyAttribute}, Value: "property can't be both readOnly and writeOnly"}
+	}
+
+	return nil
+}
+
+// Parse a value from the documentation according to the type of the property.
+func (p Property) parseValue(value string) (interface{}, error) {
+	switch p.Type {
+	case PropertyType_Integer:
+		return strconv.ParseInt(value, 10, 64)
+
+	case PropertyType_Number:
+		return strconv.ParseFloat(value, 64)
+
+	case PropertyType_Boolean:
+		return strconv.ParseBool(value)
+
+	case PropertyType_Array, PropertyType_Object:
+		var data interface{}
+		if err := json.Unmarshal([]byte(value), &data); err != nil {
+			return nil, err
+		}
+
+		return data, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// Marshal the schema with its properties in the order of their declaration, see Property.MarshalYAML.
+func (s Schema) MarshalYAML() (interface{}, error) {
+	// The inline property is encoded by its fields, so the schema has to order its properties by itself
+	type schema Schema
+
+	node := &yaml.Node{}
+	if err := node.Encode(schema(s)); err != nil {
+		return nil, err
+	}
+
+	OrderProperties(node, s.PropertiesOrder)
+	return node, nil
+}
+
+// Order the `properties` of an encoded schema (of any specification) by their positions in the given order, the properties
+// that aren't in the order are kept at the end in their encoded order (the order of their names).
+func OrderProperties(node *yaml.Node, order []string) {
+	if node.Kind != yaml.MappingNode || len(order) == 0 {
+		return
+	}
+
+	index := mappingIndex(node, "properties")
+	if index < 0 {
+		return
+	}
+
+	properties := node.Content[index+1]
+
+	positions := map[string]int{}
+	for position, name := range order {
+		positions[name] = position
+	}
+
+	rank := func(pair int) int {
+		if position, ok := positions[properties.Content[pair*2].Value]; ok {
+			return position
+		}
+
+		return len(order)
+	}
+
+	pairs := make([]int, len(properties.Content)/2)
+	for pair := range pairs {
+		pairs[pair] = pair
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool { return rank(pairs[i]) < rank(pairs[j]) })
+
+	content := make([]*yaml.Node, 0, len(properties.Content))
+	for _, pair := range pairs {
+		content = append(content, properties.Content[pair*2], properties.Content[pair*2+1])
+	}
+
+	properties.Content = content
+}
+
+// The Schema Object allows the definition of input and output data types. These types can be objects, but also primitives and arrays. This object is an extended subset of the JSON Schema Specification Wright Draft 00.
+type Schema struct {
+	// Additional external documentation for this schema.
+	ExternalDocumentation ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
 
 	// Specifies the type of the object.
 	Property `yaml:",inline"`
@@ -562,46 +853,64 @@ type Schema struct {
 // When request bodies or response payloads may be one of a number of different schemas, a discriminator object can be used to aid in serialization, deserialization, and validation. The discriminator is a specific object in a schema which is used to inform the consumer of the specification of an alternative schema based on the value associated with it.
 type Discriminator struct {
 	// REQUIRED. The name of the property in the payload that will hold the discriminator value.
-	PropertyName string `yaml:"propertyName,omitempty" validate:"required"`
+	PropertyName string `yaml:"propertyName,omitempty" json:"propertyName,omitempty" validate:"required"`
 
 	// An object to hold mappings between payload values and schema names or references.
-	Mapping map[string]string `yaml:"mapping,omitempty"`
+	Mapping map[string]string `yaml:"mapping,omitempty" json:"mapping,omitempty"`
+}
+
+// A metadata object that allows for more fine-tuned XML model definitions.
+type XML struct {
+	// Replaces the name of the element/attribute used for the described schema property. When defined within items, it will affect the name of the individual XML elements within the list. When defined alongside type being array (outside the items), it will affect the wrapping element and only if wrapped is true. If wrapped is false, it will be ignored.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// The URI of the namespace definition. Value MUST be in the form of an absolute URI.
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// The prefix to be used for the name.
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Declares whether the property definition translates to an attribute instead of an element. Default value is false.
+	Attribute bool `yaml:"attribute,omitempty" json:"attribute,omitempty"`
+
+	// MAY be used only for an array definition. Signifies whether the array is wrapped (for example, <books><book/><book/></books>) or unwrapped (<book/><book/>). Default value is false. The definition takes effect only when defined alongside type being array (outside the items).
+	Wrapped bool `yaml:"wrapped,omitempty" json:"wrapped,omitempty"`
 }
 
 // In all cases, the example value is expected to be compatible with the type schema of its associated value. Tooling implementations MAY choose to validate compatibility automatically, and reject the example value(s) if incompatible.
 type Example struct {
 	// A simple object to allow referencing other components in the specification, internally and externally.
-	Reference string `yaml:"$ref,omitempty" validate:"required_without=Summary Description Value ExternalValue"`
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty" validate:"required_without=Summary Description Value ExternalValue"`
 
 	// Short description for the example.
-	Summary string `yaml:"summary,omitempty"`
+	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
 
 	// Long description for the example. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// Embedded literal example. The value field and externalValue field are mutually exclusive. To represent examples of media types that cannot naturally represented in JSON or YAML, use a string value to contain the example, escaping where necessary.
-	Value interface{} `yaml:"value,omitempty"`
+	Value interface{} `yaml:"value,omitempty" json:"value,omitempty"`
 
 	// A URL that points to the literal example. This provides the capability to reference examples that cannot easily be included in JSON or YAML documents. The value field and externalValue field are mutually exclusive.
-	ExternalValue string `yaml:"externalValue,omitempty"`
+	ExternalValue string `yaml:"externalValue,omitempty" json:"externalValue,omitempty"`
 }
 
 // A single encoding definition applied to a single schema property.
 type Encoding struct {
 	// The Content-Type for encoding a specific property. Default value depends on the property type: for string with format being binary – application/octet-stream; for other primitive types – text/plain; for object - application/json; for array – the default is defined based on the inner type. The value can be a specific media type (e.g. application/json), a wildcard media type (e.g. image/*), or a comma-separated list of the two types.
-	ContentType string `yaml:"contentType,omitempty"`
+	ContentType string `yaml:"contentType,omitempty" json:"contentType,omitempty"`
 
 	// A map allowing additional information to be provided as headers, for example Content-Disposition. Content-Type is described separately and SHALL be ignored in this section. This property SHALL be ignored if the request body media type is not a multipart.
-	Headers map[string]Header `yaml:"headers,omitempty"`
+	Headers map[string]Header `yaml:"headers,omitempty" json:"headers,omitempty"`
 
 	// Describes how a specific property value will be serialized depending on its type. See Parameter Object for details on the style property. The behavior follows the same values as query parameters, including default values. This property SHALL be ignored if the request body media type is not application/x-www-form-urlencoded.
-	Style string `yaml:"style,omitempty"`
+	Style string `yaml:"style,omitempty" json:"style,omitempty"`
 
 	// When this is true, property values of type array or object generate separate parameters for each value of the array, or key-value-pair of the map. For other types of properties this property has no effect. When style is form, the default value is true. For all other styles, the default value is false. This property SHALL be ignored if the request body media type is not application/x-www-form-urlencoded.
-	Explode bool `yaml:"explode,omitempty"`
+	Explode bool `yaml:"explode,omitempty" json:"explode,omitempty"`
 
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by RFC3986 :/?#[]@!$&'()*+,;= to be included without percent-encoding. The default value is false. This property SHALL be ignored if the request body media type is not application/x-www-form-urlencoded.
-	AllowReserved bool `yaml:"allowReserved,omitempty"`
+	AllowReserved bool `yaml:"allowReserved,omitempty" json:"allowReserved,omitempty"`
 }
 
 // The Header Object follows the structure of the Parameter Object with the following changes:
@@ -613,34 +922,40 @@ type Encoding struct {
 // 3. All traits that are affected by the location MUST be applicable to a location of header (for example, style).
 type Header struct {
 	// A simple object to allow referencing other components in the specification, internally and externally.
-	Reference string `yaml:"$ref,omitempty" validate:"required_without=Description Required Deprecated AllowEmptyValue"`
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty" validate:"required_without=Description Required Deprecated AllowEmptyValue Schema"`
 
 	// A brief description of the parameter. This could contain examples of use. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// Determines whether this parameter is mandatory. If the parameter location is "path", this property is REQUIRED and its value MUST be true. Otherwise, the property MAY be included and its default value is false.
-	Required bool `yaml:"required,omitempty"`
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
 
 	// Specifies that a parameter is deprecated and SHOULD be transitioned out of usage. Default value is false.
-	Deprecated bool `yaml:"deprecated,omitempty"`
+	Deprecated bool `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
 
 	// Sets the ability to pass empty-valued parameters. This is valid only for query parameters and allows sending a parameter with an empty value. Default value is false. If style is used, and if behavior is n/a (cannot be serialized), the value of allowEmptyValue SHALL be ignored. Use of this property is NOT RECOMMENDED, as it is likely to be removed in a later revision.
-	AllowEmptyValue bool `yaml:"allowEmptyValue,omitempty"`
+	AllowEmptyValue bool `yaml:"allowEmptyValue,omitempty" json:"allowEmptyValue,omitempty"`
+
+	// The schema defining the type used for the header.
+	Schema Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
 }
 
 // Describes a single response from an API Operation, including design-time, static links to operations based on the response.
 type Response struct {
+	// A simple object to allow referencing other components in the specification, internally and externally.
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+
 	// REQUIRED. A short description of the response. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty" validate:"required"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty" validate:"required_without=Reference"`
 
 	// Maps a header name to its definition. RFC7230 states header names are case insensitive. If a response header is defined with the name "Content-Type", it SHALL be ignored.
-	Headers map[string]Header `yaml:"headers,omitempty"`
+	Headers map[string]Header `yaml:"headers,omitempty" json:"headers,omitempty"`
 
 	// A map containing descriptions of potential response payloads. The key is a media type or media type range and the value describes it. For responses that match multiple keys, only the most specific key is applicable. e.g. text/plain overrides text/*
-	Content map[string]MediaType `yaml:"content,omitempty"`
+	Content map[string]MediaType `yaml:"content,omitempty" json:"content,omitempty"`
 
 	// A map of operations links that can be followed from the response. The key of the map is a short name for the link, following the naming constraints of the names for Component Objects.
-	Links map[string]Link `yaml:"links,omitempty"`
+	Links map[string]Link `yaml:"links,omitempty" json:"links,omitempty"`
 }
 
 // The Link object represents a possible design-time link for a response. The presence of a link does not guarantee the caller's ability to successfully invoke it, rather it provides a known relationship and traversal mechanism between responses and other operations.
@@ -650,22 +965,22 @@ type Response struct {
 // For computing links, and providing instructions to execute them, a runtime expression is used for accessing values in an operation and using them as parameters while invoking the linked operation.
 type Link struct {
 	// A relative or absolute URI reference to an OAS operation. This field is mutually exclusive of the operationId field, and MUST point to an Operation Object. Relative operationRef values MAY be used to locate an existing Operation Object in the OpenAPI definition.
-	OperationReference string `yaml:"operationRef,omitempty"`
+	OperationReference string `yaml:"operationRef,omitempty" json:"operationRef,omitempty"`
 
 	// The name of an existing, resolvable OAS operation, as defined with a unique operationId. This field is mutually exclusive of the operationRef field.
-	OperationId string `yaml:"operationId,omitempty"`
+	OperationId string `yaml:"operationId,omitempty" json:"operationId,omitempty"`
 
 	// A map representing parameters to pass to an operation as specified with operationId or identified via operationRef. The key is the parameter name to be used, whereas the value can be a constant or an expression to be evaluated and passed to the linked operation. The parameter name can be qualified using the parameter location [{in}.]{name} for operations that use the same parameter name in different locations (e.g. path.id).
-	Parameters map[string]interface{} `yaml:"parameters,omitempty"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 
 	// A literal value or {expression} to use as a request body when calling the target operation.
-	RequestBody interface{} `yaml:"requestBody,omitempty"`
+	RequestBody interface{} `yaml:"requestBody,omitempty" json:"requestBody,omitempty"`
 
 	// A description of the link. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// A server object to be used by the target operation.
-	Server Server `yaml:"server,omitempty"`
+	Server Server `yaml:"server,omitempty" json:"server,omitempty"`
 }
 
 // A map of possible out-of band callbacks related to the parent operation. Each value in the map is a Path Item Object that describes a set of requests that may be initiated by the API provider and the expected responses. The key value used to identify the path item object is an expression, evaluated at runtime, that identifies a URL to use for the callback operation.
@@ -683,50 +998,144 @@ type SecurityRequirement map[string][]string
 // Holds a set of reusable objects for different aspects of the OAS. All objects defined within the components object will have no effect on the API unless they are explicitly referenced from properties outside the components object.
 type Components struct {
 	// An object to hold reusable Schema Objects.
-	Schemas map[string]Schema `yaml:"schemas,omitempty"`
+	Schemas map[string]Schema `yaml:"schemas,omitempty" json:"schemas,omitempty"`
 
 	// An object to hold reusable Response Objects.
-	Responses map[string]Response `yaml:"responses,omitempty"`
+	Responses map[string]Response `yaml:"responses,omitempty" json:"responses,omitempty"`
 
 	// An object to hold reusable Parameter Objects.
-	Parameters map[string]Parameter `yaml:"parameters,omitempty"`
+	Parameters map[string]Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
 
 	// An object to hold reusable Example Objects.
-	Examples map[string]interface{} `yaml:"examples,omitempty"`
+	Examples map[string]interface{} `yaml:"examples,omitempty" json:"examples,omitempty"`
 
 	// An object to hold reusable Request Body Objects.
-	RequestBodies map[string]RequestBody `yaml:"requestBodies,omitempty"`
+	RequestBodies map[string]RequestBody `yaml:"requestBodies,omitempty" json:"requestBodies,omitempty"`
 
 	// An object to hold reusable Header Objects.
-	Headers map[string]Header `yaml:"headers,omitempty"`
+	Headers map[string]Header `yaml:"headers,omitempty" json:"headers,omitempty"`
 
 	// An object to hold reusable Security Scheme Objects.
-	SecuritySchemes map[string]SecurityScheme `yaml:"securitySchemes,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `yaml:"securitySchemes,omitempty" json:"securitySchemes,omitempty"`
 
 	// An object to hold reusable Link Objects.
-	Links map[string]Link `yaml:"links,omitempty"`
+	Links map[string]Link `yaml:"links,omitempty" json:"links,omitempty"`
 
 	// An object to hold reusable Callback Objects.
-	Callbacks map[string]Callback `yaml:"callbacks,omitempty"`
+	Callbacks map[string]Callback `yaml:"callbacks,omitempty" json:"callbacks,omitempty"`
 }
 
 // Defines a security scheme that can be used by the operations. Supported schemes are HTTP authentication, an API key (either as a header, a cookie parameter or as a query parameter), OAuth2's common flows (implicit, password, client credentials and authorization code) as defined in RFC6749, and OpenID Connect Discovery.
 type SecurityScheme struct {
 	// REQUIRED. The type of the security scheme. Valid values are "apiKey", "http", "oauth2", "openIdConnect".
-	Type string `yaml:"type,omitempty" validate:"required"`
+	Type SecuritySchemeType `yaml:"type,omitempty" json:"type,omitempty" validate:"required"`
 
 	// A short description for security scheme. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// REQUIRED for apiKey. The name of the header, query or cookie parameter to be used.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// REQUIRED for apiKey. The location of the API key. Valid values are "query", "header" or "cookie".
+	In ParameterLocation `yaml:"in,omitempty" json:"in,omitempty"`
+
+	// REQUIRED for http. The name of the HTTP Authorization scheme to be used in the Authorization header as defined in RFC7235. The values used SHOULD be registered in the IANA Authentication Scheme registry.
+	Scheme string `yaml:"scheme,omitempty" json:"scheme,omitempty"`
+
+	// A hint to the client to identify how the bearer token is formatted. Bearer tokens are usually generated by an authorization server, so this information is primarily for documentation purposes.
+	BearerFormat string `yaml:"bearerFormat,omitempty" json:"bearerFormat,omitempty"`
+
+	// REQUIRED for oauth2. An object containing configuration information for the flow types supported.
+	Flows OAuthFlows `yaml:"flows,omitempty" json:"flows,omitempty"`
+
+	// REQUIRED for openIdConnect. OpenId Connect URL to discover OAuth2 configuration values. This MUST be in the form of a URL.
+	OpenIdConnectUrl string `yaml:"openIdConnectUrl,omitempty" json:"openIdConnectUrl,omitempty"`
+}
+
+// Check that the security scheme has all the fields that are required by its type.
+func (scheme SecurityScheme) Validate() error {
+	switch scheme.Type {
+	case SecuritySchemeTypeApiKey:
+		if scheme.Name == "" {
+			return InvalidSecuritySchemeError{Reason: "missing `name` of apiKey"}
+		} else if scheme.In != ParameterLocationHeader && scheme.In != ParameterLocationQuery && scheme.In != ParameterLocationCookie {
+			return InvalidSecuritySchemeError{Reason: fmt.Sprintf("invalid location `%s` of apiKey", scheme.In)}
+		}
+
+	case SecuritySchemeTypeHttp:
+		if scheme.Scheme == "" {
+			return InvalidSecuritySchemeError{Reason: "missing `scheme` of http"}
+		}
+
+	case SecuritySchemeTypeOAuth2:
+		if scheme.Flows == (OAuthFlows{}) {
+			return InvalidSecuritySchemeError{Reason: "missing `flows` of oauth2"}
+		}
+
+	case SecuritySchemeTypeOpenIdConnect:
+		if scheme.OpenIdConnectUrl == "" {
+			return InvalidSecuritySchemeError{Reason: "missing `openIdConnectUrl` of openIdConnect"}
+		}
+
+	default:
+		return InvalidSecuritySchemeError{Reason: fmt.Sprintf("invalid type `%s`", scheme.Type)}
+	}
+
+	return nil
+}
+
+// Whether the security requirements of the scheme can list the scopes that are required for the execution.
+func (scheme SecurityScheme) HasScopes() bool {
+	return scheme.Type == SecuritySchemeTypeOAuth2 || scheme.Type == SecuritySchemeTypeOpenIdConnect
+}
+
+type SecuritySchemeType string
+
+const (
+	SecuritySchemeTypeApiKey        SecuritySchemeType = "apiKey"
+	SecuritySchemeTypeHttp          SecuritySchemeType = "http"
+	SecuritySchemeTypeOAuth2        SecuritySchemeType = "oauth2"
+	SecuritySchemeTypeOpenIdConnect SecuritySchemeType = "openIdConnect"
+)
+
+// Allows configuration of the supported OAuth Flows.
+type OAuthFlows struct {
+	// Configuration for the OAuth Implicit flow.
+	Implicit *OAuthFlow `yaml:"implicit,omitempty" json:"implicit,omitempty"`
+
+	// Configuration for the OAuth Resource Owner Password flow.
+	Password *OAuthFlow `yaml:"password,omitempty" json:"password,omitempty"`
+
+	// Configuration for the OAuth Client Credentials flow. Previously called application in OpenAPI 2.0.
+	ClientCredentials *OAuthFlow `yaml:"clientCredentials,omitempty" json:"clientCredentials,omitempty"`
+
+	// Configuration for the OAuth Authorization Code flow. Previously called accessCode in OpenAPI 2.0.
+	AuthorizationCode *OAuthFlow `yaml:"authorizationCode,omitempty" json:"authorizationCode,omitempty"`
+}
+
+// Configuration details for a supported OAuth Flow.
+type OAuthFlow struct {
+	// REQUIRED for implicit and authorizationCode. The authorization URL to be used for this flow. This MUST be in the form of a URL.
+	AuthorizationUrl string `yaml:"authorizationUrl,omitempty" json:"authorizationUrl,omitempty"`
+
+	// REQUIRED for password, clientCredentials and authorizationCode. The token URL to be used for this flow. This MUST be in the form of a URL.
+	TokenUrl string `yaml:"tokenUrl,omitempty" json:"tokenUrl,omitempty"`
+
+	// The URL to be used for obtaining refresh tokens. This MUST be in the form of a URL.
+	RefreshUrl string `yaml:"refreshUrl,omitempty" json:"refreshUrl,omitempty"`
+
+	// REQUIRED. The available scopes for the OAuth2 security scheme. A map between the scope name and a short description for it. The map MAY be empty.
+	Scopes map[string]string `yaml:"scopes" json:"scopes"`
 }
 
 // Adds metadata to a single tag that is used by the Operation Object. It is not mandatory to have a Tag Object per tag defined in the Operation Object instances.
 type Tag struct {
 	// REQUIRED. The name of the tag.
-	Name string `yaml:"name,omitempty" validate:"required"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty" validate:"required"`
 
 	// A short description for the tag. CommonMark syntax MAY be used for rich text representation.
-	Description string `yaml:"description,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 
 	// Additional external documentation for this tag.
-	ExternalDocs ExternalDocumentation `yaml:"externalDocs,omitempty"`
+	ExternalDocs ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
 }
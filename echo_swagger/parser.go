@@ -1,6 +1,7 @@
 package echo_swagger
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
@@ -11,15 +12,54 @@ import (
 )
 
 type Context struct {
-	OpenAPI        *OpenAPI
+	OpenAPI *OpenAPI
+
+	// Whether to discover the routes of the handlers from their registration on echo, see discoverRoutes
+	DiscoverRoutes bool
+
+	// How the request handlers are detected, structures that their name ends with `Request` by default
+	HandlerDetection HandlerDetection
+
+	// The fully qualified name of the interface that the request handlers implement when they are detected by
+	// an interface (e.g. `example.com/api.Handler`), the `RequestHandler` interface is used when it's empty
+	HandlerInterface string
+
 	directory      string
 	packagesConfig *packages.Config
 	pkg            *packages.Package
 	file           *ast.File
+
+	// Maps between a fully qualified type (package path and type name) and its component schema name
+	componentNames map[string]string
+
+	// The component names of the named types that are currently being parsed, used to detect recursive types.
+	// The value is set to true whenever the type was referenced by itself.
+	visiting map[string]bool
+
+	// The documentation of every struct field, type and constant in the loaded packages, by the position of its name
+	docs map[token.Pos]*ast.CommentGroup
+
+	// The schemas of the well known types by their fully qualified name, see RegisterType
+	knownTypes map[string]Property
+
+	// The fully qualified names of the types that a warning was already reported for
+	reportedTypes map[string]bool
+
+	// The responses that are shared between the operations, see parseSharedResponse
+	sharedResponses []sharedResponse
+
+	// The response of the operations that don't declare any response, see Config.DefaultResponse
+	defaultResponse *DefaultResponse
+
+	// The routes that were discovered for the request types, by their fully qualified names
+	discoveredRoutes map[string][]discoveredRoute
+
+	// The resolved interface of the request handlers, see HandlerInterface
+	handlerInterface *types.Interface
 }
 
 func NewContext() *Context {
-	return &Context{
+	context := &Context{
 		OpenAPI: &OpenAPI{
 			OpenAPI:  OpenApiVersion,
 			Servers:  []Server{},
@@ -27,10 +67,26 @@ func NewContext() *Context {
 			Security: []SecurityRequirement{},
 			Tags:     []Tag{},
 		},
+		componentNames:   map[string]string{},
+		visiting:         map[string]bool{},
+		docs:             map[token.Pos]*ast.CommentGroup{},
+		knownTypes:       map[string]Property{},
+		reportedTypes:    map[string]bool{},
+		discoveredRoutes: map[string][]discoveredRoute{},
 	}
+
+	for typeName, property := range wellKnownTypes {
+		context.RegisterType(typeName, property)
+	}
+
+	return context
 }
 
 func (context *Context) ParseDirectory(directory string, pattern string) error {
+	if err := context.HandlerDetection.Validate(); err != nil {
+		return err
+	}
+
 	fset := token.NewFileSet()
 
 	context.directory = directory
@@ -39,7 +95,10 @@ func (context *Context) ParseDirectory(directory string, pattern string) error {
 		Mode: packages.NeedName |
 			packages.NeedTypes |
 			packages.NeedSyntax |
-			packages.NeedTypesInfo,
+			packages.NeedTypesInfo |
+			packages.NeedImports |
+			packages.NeedDeps |
+			packages.NeedTypesSizes,
 
 		Fset: fset,
 
@@ -51,13 +110,98 @@ func (context *Context) ParseDirectory(directory string, pattern string) error {
 		return err
 	}
 
+	if context.HandlerDetection == HandlerDetectionInterface {
+		if context.handlerInterface, err = context.resolveHandlerInterface(pkgs); err != nil {
+			return err
+		}
+	}
+
+	for _, pkg := range pkgs {
+		context.indexDocs(pkg)
+
+		if context.DiscoverRoutes {
+			context.discoverRoutes(pkg)
+		}
+	}
+
 	for _, pkg := range pkgs {
 		if err := context.parseTypesFromPackage(pkg); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	context.attachSharedResponses()
+
+	return context.applyDefaultResponse()
+}
+
+// Index the documentation of all the struct fields, type and constant declarations in the package,
+// so it can be found from the type information.
+func (context *Context) indexDocs(pkg *packages.Package) {
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.Field:
+				if node.Doc == nil {
+					return true
+				}
+
+				for _, name := range node.Names {
+					context.docs[name.Pos()] = node.Doc
+				}
+
+			case *ast.GenDecl:
+				if node.Tok != token.TYPE && node.Tok != token.CONST {
+					return true
+				}
+
+				for _, spec := range node.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						context.indexDoc(spec.Name, spec.Doc, node)
+
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							context.indexDoc(name, spec.Doc, node)
+						}
+					}
+				}
+			}
+
+			return true
+		})
+	}
+}
+
+// Index the documentation of a declared name, the documentation of the declaration itself is used
+// when it declares only a single spec.
+func (context *Context) indexDoc(name *ast.Ident, doc *ast.CommentGroup, decl *ast.GenDecl) {
+	if doc == nil && len(decl.Specs) == 1 {
+		doc = decl.Doc
+	}
+
+	if doc != nil {
+		context.docs[name.Pos()] = doc
+	}
+}
+
+// Returns the attributes from the documentation of a declared object (a struct field, a type or a constant).
+func (context *Context) objectAttributes(obj types.Object) (commentAttributes, error) {
+	attributes := make(commentAttributes)
+	if err := attributes.FromComments(context.docs[obj.Pos()].Text()); err != nil {
+		return nil, wrapError(err, "failed to extract attributes of `%s`", obj.Name())
+	}
+
+	return attributes, nil
+}
+
+// Returns the position of the object in the source code as `file:line:column`, used for error reporting.
+func (context *Context) position(pos token.Pos) string {
+	if context.packagesConfig == nil || context.packagesConfig.Fset == nil || !pos.IsValid() {
+		return "unknown position"
+	}
+
+	return context.packagesConfig.Fset.Position(pos).String()
 }
 
 func (context *Context) parseTypesFromPackage(pkg *packages.Package) error {
@@ -73,11 +217,6 @@ func (context *Context) parseTypesFromPackage(pkg *packages.Package) error {
 				return true
 			}
 
-			documentation := ""
-			if len(node.Specs) == 1 && node.Doc != nil {
-				documentation = node.Doc.Text()
-			}
-
 			for _, spec := range node.Specs {
 				spec, ok := spec.(*ast.TypeSpec)
 				if !ok {
@@ -85,6 +224,15 @@ func (context *Context) parseTypesFromPackage(pkg *packages.Package) error {
 					continue
 				}
 
+				// Every spec of a grouped declaration has its own documentation, the documentation of the declaration
+				// itself belongs to the spec only when it's the single spec of the declaration
+				documentation := ""
+				if spec.Doc != nil {
+					documentation = spec.Doc.Text()
+				} else if len(node.Specs) == 1 && node.Doc != nil {
+					documentation = node.Doc.Text()
+				}
+
 				structType, ok := spec.Type.(*ast.StructType)
 				if !ok {
 					// Check if the spec is a struct type, if no let's continue the inspection.
@@ -93,29 +241,48 @@ func (context *Context) parseTypesFromPackage(pkg *packages.Package) error {
 
 				structName := spec.Name.Name
 
-				if !strings.HasSuffix(strings.ToLower(structName), "request") {
-					// We want only structures that end with "Request".
+				var named *types.Named
+				if obj := context.pkg.TypesInfo.Defs[spec.Name]; obj != nil {
+					named, _ = obj.Type().(*types.Named)
+				}
+
+				if named == nil || !context.isRequestHandler(named) {
+					// We want only request handlers, besides the shared responses.
+					if err := context.parseSharedResponse(spec, documentation); err != nil {
+						log.Warning("directory: ", context.directory, ", package: ", context.pkg.Name, ", file: ", context.file.Name.Name, " shared response: ", structName, " - ", err)
+					}
+
 					continue
-				} else if spec.Doc == nil && documentation == "" {
-					// We want only structures that have a comments to parse their attributes.
+				}
+
+				if documentation == "" && (len(context.discoveredRoutes[qualifiedTypeName(named)]) == 0) {
+					// We want only structures that have a comments to parse their attributes, or that their routes were discovered.
 					log.Debug("Handler", structName, "found without attributes, skipping...")
 					continue
-				} else if spec.Doc != nil {
-					documentation = spec.Doc.Text()
 				}
 
 				attributes := make(commentAttributes)
 				if err := attributes.FromComments(documentation); err != nil {
 					log.Warning("directory: ", context.directory, ", package: ", context.pkg.Name, ", file: ", context.file.Name.Name, " request: ", structName, " - failed to extract attributes: ", err)
 					continue
-				} else if err := attributes.RequiredAttributes(RouteAttribute, MethodAttribute); err != nil {
-					log.Warning("directory: ", context.directory, ", package: ", context.pkg.Name, ", file: ", context.file.Name.Name, " request: ", structName, " - ", err)
-					continue
 				}
 
-				if err := context.parseStruct(structName, attributes, structType); err != nil {
-					log.Warning("directory: ", context.directory, ", package: ", context.pkg.Name, ", file: ", context.file.Name.Name, " request: ", structName, " - ", err)
-					continue
+				for _, attributes := range context.handlerAttributes(named, attributes) {
+					// Webhooks are identified by their names instead of their routes
+					required := []string{RouteAttribute, MethodAttribute}
+					if attributes.HasKey(WebhookAttribute) {
+						required = []string{WebhookAttribute, MethodAttribute}
+					}
+
+					if err := attributes.RequiredAttributes(required...); err != nil {
+						log.Warning("directory: ", context.directory, ", package: ", context.pkg.Name, ", file: ", context.file.Name.Name, " request: ", structName, " - ", err)
+						continue
+					}
+
+					if err := context.parseStruct(structName, attributes, structType); err != nil {
+						log.Warning("directory: ", context.directory, ", package: ", context.pkg.Name, ", file: ", context.file.Name.Name, " request: ", structName, " - ", err)
+						continue
+					}
 				}
 			}
 
@@ -127,13 +294,9 @@ func (context *Context) parseTypesFromPackage(pkg *packages.Package) error {
 }
 
 func (context *Context) parseStruct(name string, attributes commentAttributes, structType *ast.StructType) error {
-	route := attributes[RouteAttribute]
+	route := normalizeRoute(attributes[RouteAttribute])
 	method := attributes[MethodAttribute]
 
-	if _, exists := context.OpenAPI.Paths[route]; !exists {
-		context.OpenAPI.Paths[route] = &Path{}
-	}
-
 	operation := &Operation{
 		Summary:     attributes.GetOrDefault(SummaryAttribute),
 		Description: attributes.GetOrDefault(DescriptionAttribute),
@@ -142,9 +305,38 @@ func (context *Context) parseStruct(name string, attributes commentAttributes, s
 		Tags:        parseStringByQuotesAndSpaces(attributes.GetOrDefault(TagsAttribute)),
 	}
 
+	if attributes.HasKey(SecurityAttribute) {
+		security, err := context.parseSecurity(attributes[SecurityAttribute])
+		if err != nil {
+			return err
+		}
+
+		operation.Security = security
+	}
+
+	accept := []string{ContentTypeJson}
+	if attributes.HasKey(AcceptAttribute) {
+		contentTypes, err := parseContentTypes(attributes, AcceptAttribute)
+		if err != nil {
+			return err
+		}
+
+		accept = contentTypes
+	}
+
+	produce := []string{ContentTypeJson}
+	if attributes.HasKey(ProduceAttribute) {
+		contentTypes, err := parseContentTypes(attributes, ProduceAttribute)
+		if err != nil {
+			return err
+		}
+
+		produce = contentTypes
+	}
+
 	for _, field := range structType.Fields.List {
 		if field.Names == nil {
-			// We are searching for the Body/Header/Query/Pat attributes.
+			// We are searching for the Body/Header/Query/Path/Cookie attributes.
 			continue
 		}
 
@@ -154,7 +346,7 @@ func (context *Context) parseStruct(name string, attributes commentAttributes, s
 
 		switch fieldName {
 		case BodyField:
-			err = context.parseBody(operation, field)
+			err = context.parseBody(operation, field, accept)
 
 		case HeaderField:
 			err = context.parseParameter(operation, "header", field)
@@ -164,10 +356,13 @@ func (context *Context) parseStruct(name string, attributes commentAttributes, s
 
 		case PathField:
 			err = context.parseParameter(operation, "path", field)
+
+		case CookieField:
+			err = context.parseParameter(operation, "cookie", field)
 		}
 
 		if strings.HasSuffix(fieldName, ResponseFieldSuffix) {
-			err = context.parseResponse(operation, field)
+			err = context.parseResponse(operation, field, produce)
 		}
 
 		if err != nil {
@@ -175,6 +370,21 @@ func (context *Context) parseStruct(name string, attributes commentAttributes, s
 		}
 	}
 
+	operation.SortParameters()
+
+	if attributes.HasKey(WebhookAttribute) {
+		return context.addWebhook(attributes[WebhookAttribute], method, operation)
+	}
+
+	if missing, extra := validatePathParameters(route, operation); len(missing) > 0 || len(extra) > 0 {
+		return PathParametersMismatchError{Handler: name, Route: route, Missing: missing, Extra: extra, Position: context.position(structType.Pos())}
+	}
+
+	// The path is added only once the handler was parsed successfully, so failed handlers don't leave empty paths behind
+	if _, exists := context.OpenAPI.Paths[route]; !exists {
+		context.OpenAPI.Paths[route] = &Path{}
+	}
+
 	if err := context.OpenAPI.Paths[route].SetOperationByMethod(method, operation); err != nil {
 		return wrapError(err, "route `%s`", route)
 	}
@@ -182,49 +392,153 @@ func (context *Context) parseStruct(name string, attributes commentAttributes, s
 	return nil
 }
 
-func (context *Context) parseBody(operation *Operation, field *ast.Field) error {
+// Add the operation of a webhook handler to the webhooks of the specifications, by the name of the webhook.
+// The webhooks are emitted only by OpenAPI 3.1, see OpenAPI.Marshal.
+func (context *Context) addWebhook(name string, method string, operation *Operation) error {
+	if context.OpenAPI.Webhooks == nil {
+		context.OpenAPI.Webhooks = map[string]*Path{}
+	}
+
+	if _, exists := context.OpenAPI.Webhooks[name]; !exists {
+		context.OpenAPI.Webhooks[name] = &Path{}
+	}
+
+	if err := context.OpenAPI.Webhooks[name].SetOperationByMethod(method, operation); err != nil {
+		return wrapError(err, "webhook `%s`", name)
+	}
+
+	return nil
+}
+
+// Parse the body of the request for every content type it accepts, the fields are named by the tag of the content type.
+func (context *Context) parseBody(operation *Operation, field *ast.Field, contentTypes []string) error {
 	t := context.pkg.TypesInfo.TypeOf(field.Type)
 	if t == nil {
 		return TypeNotFoundError{TypeName: types.ExprString(field.Type)}
 	}
 
-	property, err := context.parseProperty(t, JsonTag)
-	if err != nil {
-		return err
-	}
-
 	attributes := make(commentAttributes)
 	if err := attributes.FromComments(field.Doc.Text()); err != nil {
 		return wrapError(err, "failed to extract attributes")
 	}
 
-	property.Description = attributes.GetOrDefault(DescriptionAttribute)
-	property.Required = attributes.HasKey(RequiredAttribute)
-
 	operation.RequestBody = RequestBody{
-		Content: map[string]MediaType{
-			ContentTypeJson: {
-				Schema: Schema{
-					Property: *property,
-				},
+		Content: map[string]MediaType{},
+	}
+
+	for _, contentType := range contentTypes {
+		property, err := context.parseProperty(t, contentTypeTag(contentType))
+		if err != nil {
+			return wrapError(err, "content type `%s`", contentType)
+		} else if property == nil {
+			continue
+		}
+
+		if !property.IsReference() {
+			// Siblings of a reference are ignored, so the description is set only on inline schemas
+			property.Description = attributes.GetOrDefault(DescriptionAttribute)
+		}
+
+		property.Required = attributes.HasKey(RequiredAttribute)
+
+		if contentType == ContentTypeUrlEncodedForm {
+			context.removeBinaryProperties(property, field.Pos())
+		}
+
+		mediaType := MediaType{
+			Schema: Schema{
+				Property: *property,
 			},
-		},
+		}
+
+		if contentType == ContentTypeMultipartForm {
+			mediaType.Encoding = multipartEncoding(property)
+		}
+
+		operation.RequestBody.Content[contentType] = mediaType
 	}
 
 	return nil
 }
 
+// URL encoded forms can't contain files, so the binary properties (e.g. `*multipart.FileHeader`) are removed from
+// their schema with a warning, and are described only by `multipart/form-data`.
+func (context *Context) removeBinaryProperties(property *Property, pos token.Pos) {
+	var order []string
+	for _, name := range property.PropertiesOrder {
+		if !isBinaryProperty(property.Properties[name]) {
+			order = append(order, name)
+			continue
+		}
+
+		log.Warning(context.position(pos), ": property `", name, "` is a file, which can't be sent in `", ContentTypeUrlEncodedForm, "` bodies, it is described only by `", ContentTypeMultipartForm, "`")
+		delete(property.Properties, name)
+	}
+
+	var required []string
+	for _, name := range property.RequiredProperties {
+		if _, exists := property.Properties[name]; exists {
+			required = append(required, name)
+		}
+	}
+
+	property.PropertiesOrder = order
+	property.RequiredProperties = required
+}
+
+// Check whether the property is a file or a list of files.
+func isBinaryProperty(property Property) bool {
+	if items, ok := property.Items.(Property); ok && property.Type == PropertyType_Array {
+		return items.Format == PropertyFormat_Binary
+	}
+
+	return property.Format == PropertyFormat_Binary
+}
+
+// Returns the encoding of the parts of a multipart body that declared their content type with the `@contentType` attribute.
+func multipartEncoding(property *Property) map[string]Encoding {
+	var encoding map[string]Encoding
+
+	for name, part := range property.Properties {
+		if part.ContentType == "" {
+			continue
+		} else if encoding == nil {
+			encoding = make(map[string]Encoding)
+		}
+
+		encoding[name] = Encoding{ContentType: part.ContentType}
+	}
+
+	return encoding
+}
+
 func (context *Context) parseParameter(operation *Operation, in ParameterLocation, field *ast.Field) error {
 	t := context.pkg.TypesInfo.TypeOf(field.Type)
 	if t == nil {
 		return TypeNotFoundError{TypeName: types.ExprString(field.Type)}
 	}
 
+	parameters, err := context.parseParameters(in, t)
+	if err != nil {
+		return err
+	}
+
+	for _, parameter := range parameters {
+		operation.AddParameter(in, parameter)
+	}
+
+	return nil
+}
+
+// Parse the fields of a parameters section (e.g. `Query` or `Header`) into parameters, which are named by their binder tags.
+func (context *Context) parseParameters(in ParameterLocation, t types.Type) ([]*Parameter, error) {
 	structType, ok := t.Underlying().(*types.Struct)
 	if !ok {
-		return UnsupportedTypeError{ExpectedType: "struct", ActualType: t.Underlying().String()}
+		return nil, UnsupportedTypeError{ExpectedType: "struct", ActualType: t.Underlying().String()}
 	}
 
+	parameters := []*Parameter{}
+
 	for fieldIndex := 0; fieldIndex < structType.NumFields(); fieldIndex++ {
 		field := structType.Field(fieldIndex)
 		fieldTag := structType.Tag(fieldIndex)
@@ -232,29 +546,30 @@ func (context *Context) parseParameter(operation *Operation, in ParameterLocatio
 		// Check if the field is embedded.
 		// Only embedded fields that are structures are allowed
 		if field.Embedded() {
-			_, ok := field.Type().Underlying().(*types.Struct)
+			fieldType := field.Type()
+			if pointer, ok := fieldType.(*types.Pointer); ok {
+				fieldType = pointer.Elem()
+			}
+
+			_, ok := fieldType.Underlying().(*types.Struct)
 			if !ok {
-				return UnsupportedTypeError{ExpectedType: "struct", ActualType: t.Underlying().String(), Embedded: true}
+				return nil, UnsupportedTypeError{ExpectedType: "struct", ActualType: t.Underlying().String(), Embedded: true}
 			}
 
-			embeddedProperty, err := context.parseProperty(field.Type(), BinderTag)
+			embeddedProperty, err := context.parseProperty(fieldType, BinderTag)
 			if err != nil {
-				return wrapError(err, "failed to parse embedded field", field.Type().Underlying().String())
+				return nil, wrapError(err, "failed to parse embedded field `%s`", fieldType.Underlying().String())
 			}
 
-			for _, property := range embeddedProperty.Properties {
+			// The parameters of the embedded structure are added in the order of their declaration
+			for _, name := range embeddedProperty.PropertiesOrder {
+				property := embeddedProperty.Properties[name]
 				if property.IgnoreProperty() {
 					// Ignore the property
 					continue
 				}
 
-				operation.AddParameter(in, &Parameter{
-					Name:     property.Name,
-					Required: property.Required,
-					Schema: Schema{
-						Property: property,
-					},
-				})
+				parameters = append(parameters, newParameter(&property))
 			}
 
 			continue
@@ -262,40 +577,57 @@ func (context *Context) parseParameter(operation *Operation, in ParameterLocatio
 
 		fieldProperty, err := context.parseProperty(field.Type(), BinderTag)
 		if err != nil {
-			return wrapError(err, "failed to parse field `%s`", field.Name())
+			return nil, wrapError(err, "failed to parse field `%s`", field.Name())
 		}
 
 		if err := fieldProperty.ParseTags(fieldTag, BinderTag, field.Name()); err != nil {
-			return wrapError(err, "failed to parse field `%s` tags", field.Name())
+			return nil, wrapError(err, "failed to parse field `%s` tags", field.Name())
 		} else if fieldProperty.IgnoreProperty() {
 			// Ignore the property
 			continue
 		}
 
+		attributes, err := context.objectAttributes(field)
+		if err != nil {
+			return nil, err
+		} else if err := fieldProperty.ParseAttributes(attributes); err != nil {
+			return nil, wrapError(err, "failed to parse field `%s` attributes", field.Name())
+		}
+
 		if fieldProperty.Type == PropertyType_None || fieldProperty.Type == PropertyType_Map || fieldProperty.Type == PropertyType_Object {
-			return UnsupportedTypeError{ExpectedType: "primitive/slice of primitives", ActualType: field.Type().String()}
+			return nil, UnsupportedTypeError{ExpectedType: "primitive/slice of primitives", ActualType: field.Type().String()}
 		} else if in != "query" && fieldProperty.Type == PropertyType_Array {
-			return UnsupportedTypeError{ExpectedType: "primitives", ActualType: field.Type().String()}
+			return nil, UnsupportedTypeError{ExpectedType: "primitives", ActualType: field.Type().String()}
 		}
 
 		if in == "path" {
 			fieldProperty.Required = true
 		}
 
-		// Add the parameter to the operation
-		operation.AddParameter(in, &Parameter{
-			Name:     fieldProperty.Name,
-			Required: fieldProperty.Required,
-			Schema: Schema{
-				Property: *fieldProperty,
-			},
-		})
+		parameters = append(parameters, newParameter(fieldProperty))
 	}
 
-	return nil
+	return parameters, nil
 }
 
-func (context *Context) parseResponse(operation *Operation, field *ast.Field) error {
+// Creates a parameter from a property, the description and deprecation of the property are moved to the parameter itself.
+func newParameter(property *Property) *Parameter {
+	schema := *property
+	schema.Description = ""
+	schema.Deprecated = false
+
+	return &Parameter{
+		Name:        property.Name,
+		Description: property.Description,
+		Required:    property.Required,
+		Deprecated:  property.Deprecated,
+		Schema: Schema{
+			Property: schema,
+		},
+	}
+}
+
+func (context *Context) parseResponse(operation *Operation, field *ast.Field, contentTypes []string) error {
 	t := context.pkg.TypesInfo.TypeOf(field.Type)
 	if t == nil {
 		return TypeNotFoundError{TypeName: types.ExprString(field.Type)}
@@ -308,40 +640,307 @@ func (context *Context) parseResponse(operation *Operation, field *ast.Field) er
 		return err
 	}
 
-	property, err := context.parseProperty(t, JsonTag)
+	response, err := context.newResponse(t, attributes, contentTypes)
 	if err != nil {
 		return err
-	} else if property == nil {
-		return nil
 	}
 
-	property.Description = attributes[DescriptionAttribute]
+	if err := operation.AddResponse(attributes[ResponseAttribute], response); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Creates a response from its type for every content type the handler produces, unless the response overrides them with the `@contentType` attribute.
+// Responses of empty structures (or of types that can't be described) have no content at all.
+func (context *Context) newResponse(t types.Type, attributes commentAttributes, contentTypes []string) (*Response, error) {
+	if attributes.HasKey(ContentTypeAttribute) {
+		overrides, err := parseContentTypes(attributes, ContentTypeAttribute)
+		if err != nil {
+			return nil, err
+		}
+
+		contentTypes = overrides
+	}
+
+	headers, t, err := context.parseResponseHeaders(t)
+	if err != nil {
+		return nil, err
+	} else if t == nil || isEmptyStruct(t) {
+		// The response has no body
+		contentTypes = nil
+	}
+
 	response := Response{
 		Description: attributes[DescriptionAttribute],
-		Content: map[string]MediaType{
-			ContentTypeJson: {
-				Schema: Schema{
-					Property: *property,
-				},
+		Headers:     headers,
+	}
+
+	for _, contentType := range contentTypes {
+		property, ok := binaryProperty(t, contentType)
+		if !ok {
+			var err error
+			if property, err = context.parseProperty(t, contentTypeTag(contentType)); err != nil {
+				return nil, wrapError(err, "content type `%s`", contentType)
+			} else if property == nil {
+				continue
+			}
+		}
+
+		if !property.IsReference() {
+			property.Description = attributes[DescriptionAttribute]
+		}
+
+		if response.Content == nil {
+			response.Content = make(map[string]MediaType)
+		}
+
+		response.Content[contentType] = MediaType{
+			Schema: Schema{
+				Property: *property,
 			},
-		},
+		}
 	}
 
-	if err = operation.AddResponse(attributes[ResponseAttribute], &response); err != nil {
-		return err
+	return &response, nil
+}
+
+// Check whether the type is a structure without any fields, e.g. `struct{}`.
+func isEmptyStruct(t types.Type) bool {
+	structType, ok := t.Underlying().(*types.Struct)
+	return ok && structType.NumFields() == 0
+}
+
+// Split the `Header` section out of a response structure. The fields of the section are parsed into the headers of the response,
+// and the rest of the structure is returned as the type of the body (or nil if the structure has nothing but the section).
+func (context *Context) parseResponseHeaders(t types.Type) (map[string]Header, types.Type, error) {
+	structType, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil, t, nil
 	}
 
-	return nil
+	var headers map[string]Header
+
+	fields := []*types.Var{}
+	tags := []string{}
+
+	for fieldIndex := 0; fieldIndex < structType.NumFields(); fieldIndex++ {
+		field := structType.Field(fieldIndex)
+
+		// Only a structure describes the section, any other field (e.g. `Header string`) is a part of the body
+		_, isStruct := field.Type().Underlying().(*types.Struct)
+		if field.Name() != HeaderField || field.Embedded() || !isStruct {
+			fields = append(fields, field)
+			tags = append(tags, structType.Tag(fieldIndex))
+			continue
+		}
+
+		parameters, err := context.parseParameters(ParameterLocationHeader, field.Type())
+		if err != nil {
+			return nil, nil, wrapError(err, "failed to parse `%s`", HeaderField)
+		}
+
+		headers = make(map[string]Header)
+
+		for _, parameter := range parameters {
+			headers[parameter.Name] = Header{
+				Description: parameter.Description,
+				Required:    parameter.Required,
+				Deprecated:  parameter.Deprecated,
+				Schema:      parameter.Schema,
+			}
+		}
+	}
+
+	if headers == nil {
+		return nil, t, nil
+	} else if len(fields) == 0 {
+		return headers, nil, nil
+	}
+
+	// The body is described by the rest of the fields, which keep their positions so their documentation is still found
+	return headers, types.NewStruct(fields, tags), nil
 }
 
 func (context *Context) parseProperty(t types.Type, tag string) (*Property, error) {
+	if alias, ok := t.(*types.Alias); ok {
+		// An alias may be a well known type by its own name, e.g. `json.RawMessage` (an alias of `jsontext.Value`)
+		if property, ok := context.knownType(alias.Obj()); ok {
+			return property, nil
+		}
+
+		t = types.Unalias(alias)
+	}
+
+	named, ok := t.(*types.Named)
+	if !ok {
+		return context.parseInlineProperty(t, tag)
+	} else if property, ok := context.knownType(named.Obj()); ok {
+		return property, nil
+	}
+
+	if property, ok, err := context.typeOverride(named); err != nil {
+		return nil, wrapError(err, "failed to parse type `%s`", named.Obj().Name())
+	} else if ok {
+		return property, nil
+	} else if property, ok := context.marshalerType(named, tag); ok {
+		return property, nil
+	}
+
+	name := context.componentName(named)
+	if tag != JsonTag {
+		// Components describe the JSON representation of a type, so the representation by another tag
+		// (e.g. a recursive XML element) is registered under a name that is qualified by the tag
+		name += "-" + tag
+	}
+
+	if _, visiting := context.visiting[name]; visiting {
+		// The type is already being parsed up the stack, so cut the cycle with a reference
+		// to its component. The component itself is registered once the outer parse is done.
+		context.visiting[name] = true
+		return &Property{Reference: ComponentSchemasPrefix + name}, nil
+	}
+
+	if _, ok := named.Underlying().(*types.Interface); ok {
+		if property, ok, err := context.parseInterface(named, name, tag); err != nil {
+			return nil, wrapError(err, "failed to parse interface `%s`", named.Obj().Name())
+		} else if ok {
+			return property, nil
+		}
+	}
+
+	if property, ok, err := context.parseEnum(named); err != nil {
+		return nil, wrapError(err, "failed to parse enum `%s`", named.Obj().Name())
+	} else if ok {
+		if tag != JsonTag {
+			// Parameters are not described by components, so the enum is inlined
+			return property, nil
+		}
+
+		if _, exists := context.OpenAPI.Components.Schemas[name]; !exists {
+			context.registerComponent(name, property)
+		}
+
+		return &Property{Reference: ComponentSchemasPrefix + name}, nil
+	}
+
+	// Components describe the JSON representation of a type, so only named structures
+	// that are parsed by their json tags are registered as reusable schemas.
+	if _, ok := named.Underlying().(*types.Struct); ok && tag == JsonTag {
+		return context.parseComponent(named, tag)
+	}
+
+	context.visiting[name] = false
+	defer delete(context.visiting, name)
+
+	property, err := context.parseInlineProperty(named, tag)
+	if err != nil || property == nil || !context.visiting[name] {
+		return property, err
+	}
+
+	// The type references itself, so it can't be inlined and must become a component
+	context.registerComponent(name, property)
+	return &Property{Reference: ComponentSchemasPrefix + name}, nil
+}
+
+// Register the named type under the components schemas (only once) and return a reference to it.
+func (context *Context) parseComponent(named *types.Named, tag string) (*Property, error) {
+	name := context.componentName(named)
+	reference := &Property{Reference: ComponentSchemasPrefix + name}
+
+	if _, exists := context.OpenAPI.Components.Schemas[name]; exists {
+		return reference, nil
+	}
+
+	context.visiting[name] = false
+	defer delete(context.visiting, name)
+
+	property, err := context.parseInlineProperty(named, tag)
+	if err != nil {
+		return nil, wrapError(err, "failed to parse component `%s`", name)
+	} else if property == nil {
+		return nil, nil
+	}
+
+	context.registerComponent(name, property)
+	return reference, nil
+}
+
+func (context *Context) registerComponent(name string, property *Property) {
+	if context.OpenAPI.Components.Schemas == nil {
+		context.OpenAPI.Components.Schemas = make(map[string]Schema)
+	}
+
+	context.OpenAPI.Components.Schemas[name] = Schema{Property: *property}
+}
+
+// Returns a stable name for the component of the named type, in the format `pkg.TypeName`.
+// Types with the same name from different packages that share a name are qualified by their full package path,
+// and by a numeric suffix whenever the qualified name is taken too (e.g. `Page[b.User]` & `Page[c.User]`).
+// Instantiated generic types are named by their type arguments too, e.g. `pkg.Page_User` for `Page[User]`.
+func (context *Context) componentName(named *types.Named) string {
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return genericTypeName(named)
+	}
+
+	// The type string contains the package path and the type arguments, so it is unique for every instantiation
+	id := types.TypeString(named, nil)
+	if name, exists := context.componentNames[id]; exists {
+		return name
+	}
+
+	name := obj.Pkg().Name() + "." + genericTypeName(named)
+	if context.componentNameTaken(name) {
+		// The package path doesn't qualify the type arguments (e.g. `Page[a.User]` & `Page[b.User]`),
+		// so a numeric suffix is added until the name is unique
+		qualified := strings.ReplaceAll(obj.Pkg().Path(), "/", ".") + "." + genericTypeName(named)
+
+		name = qualified
+		for suffix := 2; context.componentNameTaken(name); suffix++ {
+			name = fmt.Sprintf("%s_%d", qualified, suffix)
+		}
+	}
+
+	context.componentNames[id] = name
+	return name
+}
+
+func (context *Context) componentNameTaken(name string) bool {
+	for _, taken := range context.componentNames {
+		if taken == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (context *Context) parseInlineProperty(t types.Type, tag string) (*Property, error) {
 	property := Property{}
 
+	if tparam, ok := t.(*types.TypeParam); ok {
+		// A type parameter that was not instantiated can be anything that satisfies its constraint,
+		// which can't be described (so a free-form schema is used) unless the constraint has a single underlying type.
+		if core := coreType(tparam); core != nil {
+			return context.parseProperty(core, tag)
+		}
+
+		return &property, nil
+	}
+
 	switch t := t.Underlying().(type) {
 	case *types.Basic:
 		property.Type, property.Format = typeAndFormatFromKind(t.Kind())
 
 	case *types.Slice:
+		if elem, ok := t.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Byte {
+			// Byte slices are encoded as base64 strings
+			property.Type, property.Format = PropertyType_String, PropertyFormat_Byte
+			break
+		}
+
 		property.Type = PropertyType_Array
 
 		items, err := context.parseProperty(t.Elem(), tag)
@@ -366,38 +965,20 @@ func (context *Context) parseProperty(t types.Type, tag string) (*Property, erro
 		property.Items = *items
 
 	case *types.Struct:
+		fields, err := context.parseStructFields(t, tag, 0, map[*types.Struct]bool{})
+		if err != nil {
+			return nil, err
+		}
+
 		property.Type = PropertyType_Object
 		property.Properties = make(map[string]Property)
 
-		for fieldIndex := 0; fieldIndex < t.NumFields(); fieldIndex++ {
-			field := t.Field(fieldIndex)
-			fieldTag := t.Tag(fieldIndex)
-
-			fieldProperty, err := context.parseProperty(field.Type(), tag)
-			if err != nil {
-				return nil, wrapError(err, "failed to parse field `%s`", field.Name())
-			} else if fieldProperty == nil {
-				continue
-			} else if err := fieldProperty.ParseTags(fieldTag, tag, field.Name()); err != nil {
-				return nil, wrapError(err, "failed to parse field `%s` tags", field.Name())
-			} else if fieldProperty.IgnoreProperty() {
-				continue
-			}
-
-			if field.Anonymous() {
-				for name, fieldProperty := range fieldProperty.Properties {
-					property.Properties[name] = fieldProperty
+		for _, field := range context.dominantStructFields(fields) {
+			property.Properties[field.property.Name] = *field.property
+			property.PropertiesOrder = append(property.PropertiesOrder, field.property.Name)
 
-					if fieldProperty.Required {
-						property.RequiredProperties = append(property.RequiredProperties, name)
-					}
-				}
-			} else {
-				property.Properties[fieldProperty.Name] = *fieldProperty
-
-				if fieldProperty.Required {
-					property.RequiredProperties = append(property.RequiredProperties, fieldProperty.Name)
-				}
+			if field.property.Required {
+				property.RequiredProperties = append(property.RequiredProperties, field.property.Name)
 			}
 		}
 
@@ -416,6 +997,9 @@ func (context *Context) parseProperty(t types.Type, tag string) (*Property, erro
 	case *types.Pointer:
 		return context.parseProperty(t.Elem(), tag)
 
+	case *types.Interface:
+		// An interface can hold any value, so a free-form schema is used
+
 	default:
 		// Invalid type, return no property but also no error.
 		return nil, nil
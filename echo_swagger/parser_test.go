@@ -25,6 +25,29 @@ func generalInfo() Info {
 	}
 }
 
+func generalConfig() Config {
+	return Config{
+		Info: generalInfo(),
+		SecuritySchemes: map[string]SecurityScheme{
+			"ApiKey": {
+				Type: SecuritySchemeTypeApiKey,
+				Name: "X-API-Key",
+				In:   ParameterLocationHeader,
+			},
+			"OAuth2": {
+				Type: SecuritySchemeTypeOAuth2,
+				Flows: OAuthFlows{
+					ClientCredentials: &OAuthFlow{
+						TokenUrl: "https://example.com/oauth/token",
+						Scopes:   map[string]string{"read:users": "Read the users"},
+					},
+				},
+			},
+		},
+		Security: []SecurityRequirement{{"ApiKey": {}}},
+	}
+}
+
 func emptyOpenapi() OpenAPI {
 	openapi := NewContext().OpenAPI
 	openapi.Info = generalInfo()
@@ -53,13 +76,16 @@ func validOpenapi() OpenAPI {
 					},
 				},
 				{
-					Name:     "Version",
-					In:       ParameterLocationHeader,
-					Required: false,
+					Name:        "Version",
+					In:          ParameterLocationHeader,
+					Description: "The version of the API",
+					Required:    false,
+					Deprecated:  true,
 					Schema: Schema{
 						Property: Property{
 							Type:     PropertyType_String,
 							Format:   PropertyFormat_None,
+							Enum:     []interface{}{"v1", "v2"},
 							Required: false,
 						},
 					},
@@ -77,13 +103,15 @@ func validOpenapi() OpenAPI {
 					},
 				},
 				{
-					Name:     "page",
-					In:       ParameterLocationQuery,
-					Required: false,
+					Name:        "page",
+					In:          ParameterLocationQuery,
+					Description: "The page number to fetch",
+					Required:    false,
 					Schema: Schema{
 						Property: Property{
 							Type:     PropertyType_Integer,
 							Format:   PropertyFormat_None,
+							Default:  int64(1),
 							Required: false,
 						},
 					},
@@ -100,6 +128,31 @@ func validOpenapi() OpenAPI {
 						},
 					},
 				},
+				{
+					Name:        "session",
+					In:          ParameterLocationCookie,
+					Description: "The session of the user",
+					Required:    true,
+					Schema: Schema{
+						Property: Property{
+							Type:     PropertyType_String,
+							Format:   PropertyFormat_None,
+							Required: true,
+						},
+					},
+				},
+				{
+					Name:     "theme",
+					In:       ParameterLocationCookie,
+					Required: false,
+					Schema: Schema{
+						Property: Property{
+							Type:     PropertyType_String,
+							Format:   PropertyFormat_None,
+							Required: false,
+						},
+					},
+				},
 				{
 					Name:     "types",
 					In:       ParameterLocationQuery,
@@ -134,21 +187,7 @@ func validOpenapi() OpenAPI {
 										Type:     PropertyType_Array,
 										Required: true,
 										Items: Property{
-											Type: PropertyType_Object,
-											Properties: map[string]Property{
-												"id": {
-													Type:   PropertyType_String,
-													Format: PropertyFormat_None,
-												},
-												"username": {
-													Type:   PropertyType_String,
-													Format: PropertyFormat_None,
-												},
-												"age": {
-													Type:   PropertyType_Integer,
-													Format: PropertyFormat_None,
-												},
-											},
+											Reference: "#/components/schemas/valid.User",
 										},
 									},
 
@@ -169,7 +208,8 @@ func validOpenapi() OpenAPI {
 						ContentTypeJson: {
 							Schema: Schema{
 								Property: Property{
-									Type: PropertyType_Object,
+									Type:        PropertyType_Object,
+									Description: "A valid response",
 									Properties: map[string]Property{
 										"id": {
 											Type:   PropertyType_String,
@@ -178,41 +218,13 @@ func validOpenapi() OpenAPI {
 										"users": {
 											Type: PropertyType_Array,
 											Items: Property{
-												Type: PropertyType_Map,
-												Properties: map[string]Property{
-													"id": {
-														Type:   PropertyType_String,
-														Format: PropertyFormat_None,
-													},
-													"username": {
-														Type:   PropertyType_String,
-														Format: PropertyFormat_None,
-													},
-													"age": {
-														Type:   PropertyType_Integer,
-														Format: PropertyFormat_None,
-													},
-												},
+												Reference: "#/components/schemas/valid.User",
 											},
 										},
 										"idUsers": {
 											Type: PropertyType_Map,
 											AdditionalProperties: Property{
-												Type: PropertyType_Map,
-												Properties: map[string]Property{
-													"id": {
-														Type:   PropertyType_String,
-														Format: PropertyFormat_None,
-													},
-													"username": {
-														Type:   PropertyType_String,
-														Format: PropertyFormat_None,
-													},
-													"age": {
-														Type:   PropertyType_Integer,
-														Format: PropertyFormat_None,
-													},
-												},
+												Reference: "#/components/schemas/valid.User",
 											},
 										},
 									},
@@ -228,7 +240,8 @@ func validOpenapi() OpenAPI {
 						ContentTypeJson: {
 							Schema: Schema{
 								Property: Property{
-									Type: PropertyType_Object,
+									Type:        PropertyType_Object,
+									Description: "A bad request response",
 									Properties: map[string]Property{
 										"error": {
 											Type:   PropertyType_String,
@@ -247,21 +260,86 @@ func validOpenapi() OpenAPI {
 						ContentTypeJson: {
 							Schema: Schema{
 								Property: Property{
-									Type: PropertyType_Object,
+									Reference: "#/components/schemas/valid.User",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/calendar"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Parameters: []Parameter{
+				{
+					Name:   "level",
+					In:     ParameterLocationQuery,
+					Schema: Schema{Property: Property{Type: PropertyType_Integer}},
+				},
+				{
+					Name:   "color",
+					In:     ParameterLocationQuery,
+					Schema: Schema{Property: Property{Type: PropertyType_String}},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The calendar",
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Object,
+									Description: "The calendar",
 									Properties: map[string]Property{
-										"id": {
-											Type:   PropertyType_String,
-											Format: PropertyFormat_None,
-										},
-										"username": {
-											Type:   PropertyType_String,
-											Format: PropertyFormat_None,
+										"date":   {Type: PropertyType_String, Format: PropertyFormat_Date},
+										"color":  {Type: PropertyType_String},
+										"opaque": {},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/events"] = &Path{
+		Post: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"202": {Description: "The events were accepted"},
+			},
+			RequestBody: RequestBody{
+				Content: map[string]MediaType{
+					ContentTypeJson: {
+						Schema: Schema{
+							Property: Property{
+								Type: PropertyType_Object,
+								Properties: map[string]Property{
+									"events": {
+										Type:  PropertyType_Array,
+										Items: Property{Reference: "#/components/schemas/valid.Event"},
+									},
+									"actor": {
+										Description: "The actor of the events",
+										AnyOf: []Property{
+											{Reference: "#/components/schemas/valid.User"},
+											{Reference: "#/components/schemas/valid.Employee"},
 										},
-										"age": {
-											Type:   PropertyType_Integer,
-											Format: PropertyFormat_None,
+										Discriminator: Discriminator{
+											PropertyName: "kind",
+											Mapping: map[string]string{
+												"user":     "#/components/schemas/valid.User",
+												"employee": "#/components/schemas/valid.Employee",
+											},
 										},
 									},
+									"metadata": {},
 								},
 							},
 						},
@@ -271,77 +349,984 @@ func validOpenapi() OpenAPI {
 		},
 	}
 
-	return openapi
-}
-
-func testProperty(assert *assert.Assertions, expected *Property, actual *Property) bool {
-	assert.Equal(expected.Type, actual.Type)
-	assert.Equal(expected.Format, actual.Format)
-	// assert.Equal(expected.Required, actual.Required)
-
-	if expected.Type == PropertyType_Array {
-		expectedItem := expected.Items.(Property)
-		actualItem := actual.Items.(Property)
-		if !testProperty(assert, &expectedItem, &actualItem) {
-			return false
-		}
-	} else if expected.Type == PropertyType_Object {
-		assert.Equal(len(expected.Properties), len(actual.Properties))
-
-		for key, expectedValue := range expected.Properties {
-			actualValue, ok := actual.Properties[key]
-			assert.True(ok)
-			if !testProperty(assert, &expectedValue, &actualValue) {
-				return false
-			}
-		}
+	uploadProperties := map[string]Property{
+		"avatar": {
+			Type:        PropertyType_String,
+			Format:      PropertyFormat_Binary,
+			Description: "The image of the avatar",
+		},
+		"attachments": {
+			Type:  PropertyType_Array,
+			Items: Property{Type: PropertyType_String, Format: PropertyFormat_Binary},
+		},
+		"caption": {
+			Type: PropertyType_String,
+		},
 	}
 
-	return true
-}
-
-func testOperation(assert *assert.Assertions, expected *Operation, actual *Operation) bool {
-	if expected == nil {
-		return assert.Nil(actual)
+	openapi.Paths["/users/{id}/avatar"] = &Path{
+		Put: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"204": {Description: "The avatar was uploaded"},
+			},
+			Parameters: []Parameter{
+				{
+					Name:     "id",
+					In:       ParameterLocationPath,
+					Required: true,
+					Schema: Schema{
+						Property: Property{
+							Type:     PropertyType_String,
+							Required: true,
+						},
+					},
+				},
+			},
+			RequestBody: RequestBody{
+				Content: map[string]MediaType{
+					ContentTypeMultipartForm: {
+						Schema: Schema{
+							Property: Property{
+								Type:       PropertyType_Object,
+								Properties: uploadProperties,
+							},
+						},
+						Encoding: map[string]Encoding{
+							"avatar": {ContentType: "image/png, image/jpeg"},
+						},
+					},
+					ContentTypeUrlEncodedForm: {
+						Schema: Schema{
+							Property: Property{
+								Type: PropertyType_Object,
+								// The files can't be sent in URL encoded forms
+								Properties: map[string]Property{
+									"caption": uploadProperties["caption"],
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
-	assert.Equal(expected.Summary, actual.Summary)
-	assert.Equal(expected.Description, actual.Description)
-	assert.Equal(expected.OperationId, actual.OperationId)
-	assert.Equal(expected.Tags, actual.Tags)
-
-	// Test the parameters
-	expectedParameters := map[string]Parameter{}
-
-	for _, expectedParameter := range expected.Parameters {
-		expectedParameters[expectedParameter.Name] = expectedParameter
+	idParameter := Parameter{
+		Name:     "id",
+		In:       ParameterLocationPath,
+		Required: true,
+		Schema: Schema{
+			Property: Property{
+				Type:     PropertyType_String,
+				Required: true,
+			},
+		},
 	}
 
-	for _, actualParameter := range actual.Parameters {
-		expectedParameter, exists := expectedParameters[actualParameter.Name]
-		if !assert.True(exists) {
-			continue
-		}
-
-		assert.Equal(expectedParameter.Deprecated, actualParameter.Deprecated)
-		assert.Equal(expectedParameter.Description, actualParameter.Description)
-		assert.Equal(expectedParameter.Name, actualParameter.Name)
-		assert.Equal(expectedParameter.Required, actualParameter.Required)
-		testProperty(assert, &expectedParameter.Schema.Property, &actualParameter.Schema.Property)
+	openapi.Paths["/reports/{id}"] = &Path{
+		Get: &Operation{
+			Tags:       []string{},
+			Parameters: []Parameter{idParameter},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The report",
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Object,
+									Description: "The report",
+									Properties: map[string]Property{
+										"rows": {
+											Type:  PropertyType_Array,
+											Items: Property{Reference: "#/components/schemas/valid.ReportRow"},
+										},
+									},
+								},
+							},
+						},
+						ContentTypeXml: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Object,
+									Description: "The report",
+									Properties: map[string]Property{
+										"row": {
+											Type: PropertyType_Array,
+											Items: Property{
+												Type: PropertyType_Object,
+												Properties: map[string]Property{
+													"id":    {Type: PropertyType_String, XML: &XML{Attribute: true}},
+													"total": {Type: PropertyType_Integer},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"404": {
+					Description: "The report was not found",
+					Content: map[string]MediaType{
+						"text/plain": {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_String,
+									Description: "The report was not found",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
-	// Test the responses
-	assert.Equal(len(expected.Responses), len(actual.Responses))
-
-	for responseName, expectedResponse := range expected.Responses {
-		actualResponse, exists := actual.Responses[responseName]
-		if !assert.True(exists) {
-			return false
-		}
-
-		assert.Equal(expectedResponse.Description, actualResponse.Description)
+	openapi.Paths["/reports/{id}/export"] = &Path{
+		Get: &Operation{
+			Tags:       []string{},
+			Parameters: []Parameter{idParameter},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The exported report",
+					Content: map[string]MediaType{
+						"text/csv": {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_String,
+									Format:      PropertyFormat_Binary,
+									Description: "The exported report",
+								},
+							},
+						},
+						"application/pdf": {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_String,
+									Format:      PropertyFormat_Binary,
+									Description: "The exported report",
+								},
+							},
+						},
+					},
+				},
+				"206": {
+					Description: "A part of the exported report",
+					Content: map[string]MediaType{
+						"text/csv": {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_String,
+									Format:      PropertyFormat_Binary,
+									Description: "A part of the exported report",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 
-		for contentType, expectedContent := range expectedResponse.Content {
+	createUser := &Operation{
+		Tags: []string{},
+		RequestBody: RequestBody{
+			Content: map[string]MediaType{
+				ContentTypeJson: {
+					Schema: Schema{
+						Property: Property{
+							Type: PropertyType_Object,
+							Properties: map[string]Property{
+								"username": {Type: PropertyType_String},
+							},
+						},
+					},
+				},
+			},
+		},
+		Responses: map[string]Response{
+			"201": {
+				Description: "The user was created",
+				Headers: map[string]Header{
+					"X-RateLimit-Remaining": {
+						Description: "The amount of requests that are left in the current window",
+						Schema:      Schema{Property: Property{Type: PropertyType_Integer}},
+					},
+					"Location": {
+						Description: "The URL of the created user",
+						Required:    true,
+						Schema:      Schema{Property: Property{Type: PropertyType_String, Required: true}},
+					},
+					"ETag": {
+						Schema: Schema{Property: Property{Type: PropertyType_String}},
+					},
+				},
+				Content: map[string]MediaType{
+					ContentTypeJson: {
+						Schema: Schema{
+							Property: Property{
+								Type:        PropertyType_Object,
+								Description: "The user was created",
+								Properties: map[string]Property{
+									"id": {Type: PropertyType_String},
+								},
+							},
+						},
+					},
+				},
+			},
+			"400": {
+				Description: "The request has an invalid header",
+				Content: map[string]MediaType{
+					ContentTypeJson: {
+						Schema: Schema{
+							Property: Property{
+								Type:        PropertyType_Object,
+								Description: "The request has an invalid header",
+								Properties: map[string]Property{
+									"header": {Type: PropertyType_String, Description: "The name of the invalid header"},
+								},
+							},
+						},
+					},
+				},
+			},
+			"429": {
+				Description: "Too many requests",
+				Headers: map[string]Header{
+					"Retry-After": {
+						Schema: Schema{Property: Property{Type: PropertyType_Integer}},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/account"] = &Path{
+		Get: &Operation{
+			Tags:     []string{},
+			Security: &[]SecurityRequirement{{"OAuth2": {"read:users"}}, {"ApiKey": {}}},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The account",
+					Content: map[string]MediaType{
+						ContentTypeJson: {Schema: Schema{Property: Property{Reference: "#/components/schemas/valid.User"}}},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/health"] = &Path{
+		Get: &Operation{
+			Tags:     []string{},
+			Security: &[]SecurityRequirement{},
+			Responses: map[string]Response{
+				"204": {Description: "The service is healthy"},
+			},
+		},
+	}
+
+	openapi.Paths["/documents"] = &Path{
+		Put: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"204": {Description: "The document was updated"},
+			},
+			RequestBody: RequestBody{
+				Content: map[string]MediaType{
+					ContentTypeJson: {
+						Schema: Schema{
+							Property: Property{
+								Type: PropertyType_Object,
+								Properties: map[string]Property{
+									"createdBy": {Type: PropertyType_String},
+									"base":      {Reference: "#/components/schemas/valid.Base"},
+									"version":   {Type: PropertyType_String},
+									"count":     {Type: PropertyType_String},
+									"enabled":   {Type: PropertyType_String},
+									"Name":      {Type: PropertyType_String},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/users"] = &Path{
+		Post: createUser,
+		Get: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"200": {
+					Description: "A page of users",
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Object,
+									Description: "A page of users",
+									Properties: map[string]Property{
+										"users":  {Reference: "#/components/schemas/valid.Page_User"},
+										"nested": {Reference: "#/components/schemas/valid.Envelope_Page_User"},
+										"lookup": {Reference: "#/components/schemas/valid.Lookup_string_User"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Webhooks = map[string]*Path{
+		"userCreated": {
+			Post: &Operation{
+				Description: "The notification that is sent whenever a user is created",
+				Tags:        []string{},
+				RequestBody: RequestBody{
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type: PropertyType_Object,
+									Properties: map[string]Property{
+										"username": {Type: PropertyType_String},
+									},
+								},
+							},
+						},
+					},
+				},
+				Responses: map[string]Response{
+					"200": {Description: "The notification was received"},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/orders"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Parameters: []Parameter{
+				{
+					Name:        "status",
+					In:          ParameterLocationQuery,
+					Description: "The status of an order",
+					Schema: Schema{
+						Property: Property{
+							Type:             PropertyType_String,
+							Enum:             []interface{}{"pending", "paid", "cancelled"},
+							EnumDescriptions: []string{"The order is waiting for a payment", "The order was paid", ""},
+						},
+					},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The orders",
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Object,
+									Description: "The orders",
+									Properties: map[string]Property{
+										"status": {Reference: "#/components/schemas/valid.Status"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/categories/{id}"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Parameters: []Parameter{
+				{
+					Name:     "id",
+					In:       ParameterLocationPath,
+					Required: true,
+					Schema: Schema{
+						Property: Property{
+							Type:     PropertyType_String,
+							Required: true,
+						},
+					},
+				},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The category with its sub categories",
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Object,
+									Description: "The category with its sub categories",
+									Properties: map[string]Property{
+										"category":   {Reference: "#/components/schemas/valid.Category"},
+										"department": {Reference: "#/components/schemas/valid.Department"},
+										"tree":       {Reference: "#/components/schemas/valid.Tree"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Components.Schemas = map[string]Schema{
+		"valid.Event": {
+			Property: Property{
+				Description: "An event that happened in the system",
+				OneOf: []Property{
+					{Reference: "#/components/schemas/valid.UserCreated"},
+					{Reference: "#/components/schemas/valid.UserDeleted"},
+				},
+				Discriminator: Discriminator{
+					PropertyName: "type",
+					Mapping: map[string]string{
+						"user.created": "#/components/schemas/valid.UserCreated",
+						"UserDeleted":  "#/components/schemas/valid.UserDeleted",
+					},
+				},
+			},
+		},
+		"valid.UserCreated": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"type": {Type: PropertyType_String},
+					"user": {Reference: "#/components/schemas/valid.User"},
+				},
+			},
+		},
+		"valid.UserDeleted": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"type": {Type: PropertyType_String},
+					"id":   {Type: PropertyType_String},
+				},
+			},
+		},
+		"valid.Base": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"id": {Type: PropertyType_String},
+				},
+			},
+		},
+		"valid.Page_User": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"items": {
+						Type:  PropertyType_Array,
+						Items: Property{Reference: "#/components/schemas/valid.User"},
+					},
+					"total": {Type: PropertyType_Integer},
+				},
+			},
+		},
+		"valid.Envelope_Page_User": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"data":  {Reference: "#/components/schemas/valid.Page_User"},
+					"error": {Type: PropertyType_String},
+				},
+			},
+		},
+		"valid.Lookup_string_User": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"key": {Type: PropertyType_String},
+					"values": {
+						Type:                 PropertyType_Map,
+						AdditionalProperties: Property{Reference: "#/components/schemas/valid.User"},
+					},
+				},
+			},
+		},
+		"valid.Status": {
+			Property: Property{
+				Type:             PropertyType_String,
+				Description:      "The status of an order",
+				Enum:             []interface{}{"pending", "paid", "cancelled"},
+				EnumDescriptions: []string{"The order is waiting for a payment", "The order was paid", ""},
+			},
+		},
+		"valid.Category": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"name":   {Type: PropertyType_String},
+					"parent": {Reference: "#/components/schemas/valid.Category"},
+					"children": {
+						Type:  PropertyType_Array,
+						Items: Property{Reference: "#/components/schemas/valid.Category"},
+					},
+				},
+			},
+		},
+		"valid.Employee": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"name":       {Type: PropertyType_String},
+					"department": {Reference: "#/components/schemas/valid.Department"},
+				},
+			},
+		},
+		"valid.Department": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"name": {Type: PropertyType_String},
+					"employees": {
+						Type:  PropertyType_Array,
+						Items: Property{Reference: "#/components/schemas/valid.Employee"},
+					},
+				},
+			},
+		},
+		"valid.Tree": {
+			Property: Property{
+				Type:                 PropertyType_Map,
+				AdditionalProperties: Property{Reference: "#/components/schemas/valid.Tree"},
+			},
+		},
+		"valid.ReportRow": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"id":    {Type: PropertyType_String},
+					"total": {Type: PropertyType_Integer},
+				},
+			},
+		},
+		"valid.User": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"id": {
+						Type:        PropertyType_String,
+						Format:      "uuid",
+						Description: "The unique identifier of the user",
+						ReadOnly:    true,
+					},
+					"username": {
+						Type:    PropertyType_String,
+						Format:  PropertyFormat_None,
+						Example: "avivatedgi",
+					},
+					"age": {
+						Type:        PropertyType_Integer,
+						Format:      PropertyFormat_None,
+						Description: "The age of the user in years",
+						Example:     int64(21),
+					},
+				},
+			},
+		},
+	}
+
+	return openapi
+}
+
+func sharedOpenapi() OpenAPI {
+	openapi := emptyOpenapi()
+
+	objectContent := func(description string, properties map[string]Property) map[string]MediaType {
+		return map[string]MediaType{
+			ContentTypeJson: {
+				Schema: Schema{
+					Property: Property{
+						Type:        PropertyType_Object,
+						Description: description,
+						Properties:  properties,
+					},
+				},
+			},
+		}
+	}
+
+	openapi.Paths["/me"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The current user",
+					Content:     objectContent("The current user", map[string]Property{"id": {Type: PropertyType_String}}),
+				},
+				"401": {
+					Description: "The session of the user has expired",
+					Content:     objectContent("The session of the user has expired", map[string]Property{"reason": {Type: PropertyType_String}}),
+				},
+				"429": {Reference: "#/components/responses/TooManyRequests"},
+				"5XX": {Reference: "#/components/responses/ServerError"},
+			},
+		},
+	}
+
+	// The undocumented structures of the grouped declaration neither inherit the route of the handler
+	// nor the shared response that are declared before them
+	openapi.Paths["/limits"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The rate limit",
+					Content: map[string]MediaType{
+						ContentTypeJson: {Schema: Schema{Property: Property{Reference: "#/components/schemas/shared.RateLimit"}}},
+					},
+				},
+				"401": {Reference: "#/components/responses/Unauthorized"},
+				"429": {Reference: "#/components/responses/TooManyRequests"},
+				"5XX": {Reference: "#/components/responses/ServerError"},
+			},
+		},
+	}
+
+	openapi.Paths["/admin/users/{id}"] = &Path{
+		Delete: &Operation{
+			Tags: []string{"Admin"},
+			Parameters: []Parameter{
+				{
+					Name:     "id",
+					In:       ParameterLocationPath,
+					Required: true,
+					Schema:   Schema{Property: Property{Type: PropertyType_String, Required: true}},
+				},
+			},
+			Responses: map[string]Response{
+				"default": {
+					Description: "An error",
+					Content:     objectContent("An error", map[string]Property{"message": {Type: PropertyType_String}}),
+				},
+				"401": {Reference: "#/components/responses/Unauthorized"},
+				"403": {Reference: "#/components/responses/ForbiddenResponse"},
+				"429": {Reference: "#/components/responses/TooManyRequests"},
+				"5XX": {Reference: "#/components/responses/ServerError"},
+			},
+		},
+	}
+
+	openapi.Components.Responses = map[string]Response{
+		"Unauthorized": {
+			Description: "The request is not authenticated",
+			Content: map[string]MediaType{
+				ContentTypeJson: {Schema: Schema{Property: Property{Reference: "#/components/schemas/shared.UnauthorizedResponse"}}},
+			},
+		},
+		"ForbiddenResponse": {
+			Description: "The user is not allowed to access the resource",
+			Content: map[string]MediaType{
+				ContentTypeJson: {Schema: Schema{Property: Property{Reference: "#/components/schemas/shared.ForbiddenResponse"}}},
+			},
+		},
+		"ServerError": {
+			Description: "An unexpected error",
+			Content: map[string]MediaType{
+				ContentTypeJson: {Schema: Schema{Property: Property{Reference: "#/components/schemas/shared.ServerErrorResponse"}}},
+			},
+		},
+		"TooManyRequests": {
+			Description: "Too many requests",
+			Content: map[string]MediaType{
+				ContentTypeJson: {Schema: Schema{Property: Property{Reference: "#/components/schemas/shared.TooManyRequestsResponse"}}},
+			},
+		},
+	}
+
+	openapi.Components.Schemas = map[string]Schema{
+		"shared.UnauthorizedResponse": {
+			Property: Property{
+				Type:       PropertyType_Object,
+				Properties: map[string]Property{"message": {Type: PropertyType_String}},
+			},
+		},
+		"shared.ForbiddenResponse": {
+			Property: Property{
+				Type: PropertyType_Object,
+				Properties: map[string]Property{
+					"message":    {Type: PropertyType_String},
+					"permission": {Type: PropertyType_String},
+				},
+			},
+		},
+		"shared.ServerErrorResponse": {
+			Property: Property{
+				Type:       PropertyType_Object,
+				Properties: map[string]Property{"message": {Type: PropertyType_String}},
+			},
+		},
+		"shared.TooManyRequestsResponse": {
+			Property: Property{
+				Type:       PropertyType_Object,
+				Properties: map[string]Property{"message": {Type: PropertyType_String}},
+			},
+		},
+		"shared.RateLimit": {
+			Property: Property{
+				Type:       PropertyType_Object,
+				Properties: map[string]Property{"remaining": {Type: PropertyType_Integer}},
+			},
+		},
+	}
+
+	return openapi
+}
+
+func defaultResponseOpenapi() OpenAPI {
+	openapi := emptyOpenapi()
+	openapi.Paths["/ping"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"200": {Description: "OK"},
+			},
+		},
+	}
+
+	return openapi
+}
+
+func discoveryOpenapi() OpenAPI {
+	openapi := emptyOpenapi()
+
+	idParameter := Parameter{
+		Name:     "id",
+		In:       ParameterLocationPath,
+		Required: true,
+		Schema:   Schema{Property: Property{Type: PropertyType_String, Required: true}},
+	}
+
+	usernameContent := func(description string) map[string]MediaType {
+		return map[string]MediaType{
+			ContentTypeJson: {
+				Schema: Schema{
+					Property: Property{
+						Type:        PropertyType_Object,
+						Description: description,
+						Properties:  map[string]Property{"username": {Type: PropertyType_String}},
+					},
+				},
+			},
+		}
+	}
+
+	openapi.Paths["/api/users/{id}"] = &Path{
+		Get: &Operation{
+			Tags:       []string{},
+			Parameters: []Parameter{idParameter},
+			Responses: map[string]Response{
+				"200": {Description: "The user", Content: usernameContent("The user")},
+			},
+		},
+		Patch: &Operation{
+			Summary:     "Update a user",
+			Tags:        []string{},
+			Parameters:  []Parameter{idParameter},
+			RequestBody: RequestBody{Content: usernameContent("")},
+			Responses: map[string]Response{
+				"204": {Description: "The user was updated"},
+			},
+		},
+	}
+
+	openapi.Paths["/v2/users/{id}"] = &Path{
+		Delete: &Operation{
+			Tags:       []string{},
+			Parameters: []Parameter{idParameter},
+			Responses: map[string]Response{
+				"204": {Description: "The user was deleted"},
+			},
+		},
+	}
+
+	openapi.Paths["/api/admin/audit"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"200": {
+					Description: "The audit log",
+					Content: map[string]MediaType{
+						ContentTypeJson: {
+							Schema: Schema{
+								Property: Property{
+									Type:        PropertyType_Array,
+									Description: "The audit log",
+									Items:       Property{Type: PropertyType_String},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	openapi.Paths["/internal/health"] = &Path{
+		Get: &Operation{
+			Tags: []string{},
+			Responses: map[string]Response{
+				"204": {Description: "The service is healthy"},
+			},
+		},
+	}
+
+	return openapi
+}
+
+func testProperty(assert *assert.Assertions, expected *Property, actual *Property) bool {
+	assert.Equal(expected.Reference, actual.Reference)
+	assert.Equal(expected.Type, actual.Type)
+	assert.Equal(expected.Format, actual.Format)
+	assert.Equal(expected.Description, actual.Description)
+	assert.Equal(expected.Example, actual.Example)
+	assert.Equal(expected.Default, actual.Default)
+	assert.Equal(expected.Enum, actual.Enum)
+	assert.Equal(expected.EnumDescriptions, actual.EnumDescriptions)
+	assert.Equal(expected.Deprecated, actual.Deprecated)
+	assert.Equal(expected.ReadOnly, actual.ReadOnly)
+	assert.Equal(expected.WriteOnly, actual.WriteOnly)
+	assert.Equal(expected.Discriminator, actual.Discriminator)
+	assert.Equal(expected.XML, actual.XML)
+
+	for _, subschemas := range [][2][]Property{{expected.OneOf, actual.OneOf}, {expected.AnyOf, actual.AnyOf}, {expected.AllOf, actual.AllOf}} {
+		if !assert.Equal(len(subschemas[0]), len(subschemas[1])) {
+			return false
+		}
+
+		for index := range subschemas[0] {
+			if !testProperty(assert, &subschemas[0][index], &subschemas[1][index]) {
+				return false
+			}
+		}
+	}
+	// assert.Equal(expected.Required, actual.Required)
+
+	if expected.Type == PropertyType_Array {
+		expectedItem := expected.Items.(Property)
+		actualItem := actual.Items.(Property)
+		if !testProperty(assert, &expectedItem, &actualItem) {
+			return false
+		}
+	} else if expected.Type == PropertyType_Object {
+		assert.Equal(len(expected.Properties), len(actual.Properties))
+
+		for key, expectedValue := range expected.Properties {
+			actualValue, ok := actual.Properties[key]
+			assert.True(ok)
+			if !testProperty(assert, &expectedValue, &actualValue) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func testPaths(assert *assert.Assertions, expected map[string]*Path, actual map[string]*Path) {
+	assert.Equal(len(expected), len(actual))
+
+	for pathName, expectedPath := range expected {
+		actualPath, exists := actual[pathName]
+		if !assert.True(exists, pathName) {
+			continue
+		}
+
+		for _, operation := range []string{"get", "post", "put", "delete", "options", "head", "patch", "trace"} {
+			expectedOperation, expectedErr := expectedPath.GetOperationByMethod(operation)
+			actualOperation, actualErr := actualPath.GetOperationByMethod(operation)
+
+			if expectedErr != nil {
+				assert.True(errors.Is(actualErr, expectedErr))
+				continue
+			}
+
+			testOperation(assert, expectedOperation, actualOperation)
+		}
+	}
+}
+
+func testOperation(assert *assert.Assertions, expected *Operation, actual *Operation) bool {
+	if expected == nil {
+		return assert.Nil(actual)
+	}
+
+	assert.Equal(expected.Summary, actual.Summary)
+	assert.Equal(expected.Description, actual.Description)
+	assert.Equal(expected.OperationId, actual.OperationId)
+	assert.Equal(expected.Tags, actual.Tags)
+	assert.Equal(expected.Security, actual.Security)
+
+	// Test the parameters
+	expectedParameters := map[string]Parameter{}
+
+	for _, expectedParameter := range expected.Parameters {
+		expectedParameters[expectedParameter.Name] = expectedParameter
+	}
+
+	assert.Equal(len(expected.Parameters), len(actual.Parameters))
+
+	for _, actualParameter := range actual.Parameters {
+		expectedParameter, exists := expectedParameters[actualParameter.Name]
+		if !assert.True(exists) {
+			continue
+		}
+
+		assert.Equal(expectedParameter.Deprecated, actualParameter.Deprecated)
+		assert.Equal(expectedParameter.Description, actualParameter.Description)
+		assert.Equal(expectedParameter.Name, actualParameter.Name)
+		assert.Equal(expectedParameter.In, actualParameter.In)
+		assert.Equal(expectedParameter.Required, actualParameter.Required)
+		testProperty(assert, &expectedParameter.Schema.Property, &actualParameter.Schema.Property)
+	}
+
+	// Test the responses
+	assert.Equal(len(expected.Responses), len(actual.Responses))
+
+	for responseName, expectedResponse := range expected.Responses {
+		actualResponse, exists := actual.Responses[responseName]
+		if !assert.True(exists) {
+			return false
+		}
+
+		assert.Equal(expectedResponse.Reference, actualResponse.Reference)
+		assert.Equal(expectedResponse.Description, actualResponse.Description)
+		assert.Equal(len(expectedResponse.Content), len(actualResponse.Content))
+		assert.Equal(len(expectedResponse.Headers), len(actualResponse.Headers))
+
+		for headerName, expectedHeader := range expectedResponse.Headers {
+			actualHeader, exists := actualResponse.Headers[headerName]
+			if !assert.True(exists, headerName) {
+				return false
+			}
+
+			assert.Equal(expectedHeader.Description, actualHeader.Description)
+			assert.Equal(expectedHeader.Required, actualHeader.Required)
+			testProperty(assert, &expectedHeader.Schema.Property, &actualHeader.Schema.Property)
+		}
+
+		for contentType, expectedContent := range expectedResponse.Content {
 			actualContent, exists := actualResponse.Content[contentType]
 			if !assert.True(exists) {
 				return false
@@ -361,6 +1346,7 @@ func testOperation(assert *assert.Assertions, expected *Operation, actual *Opera
 		}
 
 		testProperty(assert, &expectedContent.Schema.Property, &actualContent.Schema.Property)
+		assert.Equal(expectedContent.Encoding, actualContent.Encoding)
 	}
 
 	return true
@@ -376,6 +1362,12 @@ func TestParser(t *testing.T) {
 		operation     string
 		expectedError error
 		openapi       OpenAPI
+
+		// The response of the operations without responses, see Config.DefaultResponse
+		defaultResponse *DefaultResponse
+
+		// Whether to discover the routes from their registration on echo
+		discoverRoutes bool
 	}
 
 	testCases := []testCase{
@@ -402,6 +1394,42 @@ func TestParser(t *testing.T) {
 			expectedError: nil,
 			openapi:       validOpenapi(),
 		},
+		{
+			name:          "shared responses",
+			directory:     "../testdata/shared",
+			pattern:       "./...",
+			expectedError: nil,
+			openapi:       sharedOpenapi(),
+		},
+		{
+			name:          "missing responses",
+			directory:     "../testdata/responses",
+			pattern:       "./...",
+			expectedError: MissingResponsesError{Route: "/ping", Method: "GET"},
+		},
+		{
+			name:            "default response",
+			directory:       "../testdata/responses",
+			pattern:         "./...",
+			expectedError:   nil,
+			openapi:         defaultResponseOpenapi(),
+			defaultResponse: &DefaultResponse{StatusCode: "200", Description: "OK"},
+		},
+		{
+			name:           "discovered routes",
+			directory:      "../testdata/discovery",
+			pattern:        "./...",
+			expectedError:  nil,
+			openapi:        discoveryOpenapi(),
+			discoverRoutes: true,
+		},
+		{
+			name:          "undiscovered routes",
+			directory:     "../testdata/discovery",
+			pattern:       "./...",
+			expectedError: nil,
+			openapi:       emptyOpenapi(),
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -411,7 +1439,14 @@ func TestParser(t *testing.T) {
 		t.Log("==============================")
 
 		context := NewContext()
-		context.OpenAPI.Info = generalInfo()
+		context.DiscoverRoutes = testCase.discoverRoutes
+
+		config := generalConfig()
+		config.DefaultResponse = testCase.defaultResponse
+
+		if !assert.NoError(context.ApplyConfig(config)) {
+			continue
+		}
 
 		err := context.ParseDirectory(testCase.directory, testCase.pattern)
 
@@ -420,23 +1455,35 @@ func TestParser(t *testing.T) {
 			continue
 		}
 
-		assert.Equal(len(testCase.openapi.Paths), len(context.OpenAPI.Paths))
 		assert.Equal(testCase.openapi.Info, context.OpenAPI.Info)
 
-		for pathName, expectedPath := range testCase.openapi.Paths {
-			actualPath, exists := context.OpenAPI.Paths[pathName]
-			assert.True(exists)
+		testPaths(assert, testCase.openapi.Paths, context.OpenAPI.Paths)
+		testPaths(assert, testCase.openapi.Webhooks, context.OpenAPI.Webhooks)
+
+		assert.Equal(len(testCase.openapi.Components.Responses), len(context.OpenAPI.Components.Responses))
+
+		for responseName, expectedResponse := range testCase.openapi.Components.Responses {
+			actualResponse, exists := context.OpenAPI.Components.Responses[responseName]
+			if !assert.True(exists, responseName) {
+				continue
+			}
 
-			for _, operation := range []string{"get", "post", "put", "delete", "options", "head", "patch", "trace"} {
-				expectedOperation, expectedErr := expectedPath.GetOperationByMethod(operation)
-				actualOperation, actualErr := actualPath.GetOperationByMethod(operation)
+			assert.Equal(expectedResponse.Description, actualResponse.Description)
 
-				if expectedErr != nil {
-					assert.True(errors.Is(actualErr, expectedErr))
-					continue
+			for contentType, expectedContent := range expectedResponse.Content {
+				actualContent, exists := actualResponse.Content[contentType]
+				if assert.True(exists) {
+					testProperty(assert, &expectedContent.Schema.Property, &actualContent.Schema.Property)
 				}
+			}
+		}
+
+		assert.Equal(len(testCase.openapi.Components.Schemas), len(context.OpenAPI.Components.Schemas))
 
-				testOperation(assert, expectedOperation, actualOperation)
+		for schemaName, expectedSchema := range testCase.openapi.Components.Schemas {
+			actualSchema, exists := context.OpenAPI.Components.Schemas[schemaName]
+			if assert.True(exists, schemaName) {
+				testProperty(assert, &expectedSchema.Property, &actualSchema.Property)
 			}
 		}
 	}
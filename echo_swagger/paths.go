@@ -0,0 +1,62 @@
+package echo_swagger
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Matches the variables of a path template, e.g. `{id}` in `/users/{id}`
+var pathVariablePattern = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// Convert the parameters of an echo route (e.g. `/users/:id`) into the variables of a path template (e.g. `/users/{id}`).
+func normalizeRoute(route string) string {
+	segments := strings.Split(route, "/")
+
+	for index, segment := range segments {
+		if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+			segments[index] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// Returns the variables of a path template by their order in the path.
+func pathVariables(route string) []string {
+	variables := []string{}
+
+	for _, match := range pathVariablePattern.FindAllStringSubmatch(route, -1) {
+		variables = append(variables, match[1])
+	}
+
+	return variables
+}
+
+// Cross-check the variables of the route against the path parameters of the operation, every variable must
+// have a matching parameter and every parameter must have a matching variable.
+func validatePathParameters(route string, operation *Operation) (missing []string, extra []string) {
+	variables := map[string]bool{}
+	for _, variable := range pathVariables(route) {
+		variables[variable] = true
+	}
+
+	parameters := map[string]bool{}
+	for _, parameter := range operation.Parameters {
+		if parameter.In != ParameterLocationPath {
+			continue
+		}
+
+		parameters[parameter.Name] = true
+		if !variables[parameter.Name] {
+			extra = append(extra, parameter.Name)
+		}
+	}
+
+	for _, variable := range pathVariables(route) {
+		if !parameters[variable] {
+			missing = append(missing, variable)
+		}
+	}
+
+	return missing, extra
+}
@@ -0,0 +1,73 @@
+package echo_swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := map[string]string{
+		"/users":                    "/users",
+		"/users/:id":                "/users/{id}",
+		"/users/:id/posts/:post_id": "/users/{id}/posts/{post_id}",
+		"/users/{id}":               "/users/{id}",
+		"/users/:":                  "/users/:",
+		"/static/*":                 "/static/*",
+	}
+
+	for route, expected := range testCases {
+		assert.Equal(expected, normalizeRoute(route), route)
+	}
+}
+
+func TestValidatePathParameters(t *testing.T) {
+	assert := assert.New(t)
+
+	pathParameters := func(names ...string) *Operation {
+		operation := &Operation{}
+		operation.AddParameter(ParameterLocationQuery, &Parameter{Name: "page"})
+
+		for _, name := range names {
+			operation.AddParameter(ParameterLocationPath, &Parameter{Name: name})
+		}
+
+		return operation
+	}
+
+	type testCase struct {
+		route     string
+		operation *Operation
+		missing   []string
+		extra     []string
+	}
+
+	testCases := []testCase{
+		{
+			route:     "/users/{id}/posts/{post}",
+			operation: pathParameters("post", "id"),
+		},
+		{
+			route:     "/users",
+			operation: pathParameters(),
+		},
+		{
+			route:     "/users/{id}/posts/{post}",
+			operation: pathParameters("id"),
+			missing:   []string{"post"},
+		},
+		{
+			route:     "/users/{id}",
+			operation: pathParameters("id", "page", "name"),
+			extra:     []string{"page", "name"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		missing, extra := validatePathParameters(testCase.route, testCase.operation)
+		assert.Equal(testCase.missing, missing, testCase.route)
+		assert.Equal(testCase.extra, extra, testCase.route)
+	}
+}
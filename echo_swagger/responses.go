@@ -0,0 +1,152 @@
+package echo_swagger
+
+import (
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A response that is declared once and attached to every operation (or to the operations with one of its tags)
+// by a reference to its component.
+type sharedResponse struct {
+	statusCode string
+	name       string
+	tags       []string
+
+	// The name of the structure that declares the response
+	structure string
+}
+
+// Check that the status code of a response is a valid HTTP status code, a range of status codes (e.g. `4XX`) or `default`.
+func validateStatusCode(code string) error {
+	if code == DefaultStatusCode {
+		return nil
+	} else if len(code) != 3 || code[0] < '1' || code[0] > '5' {
+		return InvalidStatusCodeError{StatusCode: code}
+	} else if code[1:] == "XX" {
+		return nil
+	} else if _, err := strconv.Atoi(code); err != nil {
+		return InvalidStatusCodeError{StatusCode: code}
+	}
+
+	return nil
+}
+
+// Parse a structure with the `@sharedResponse <code> [name]` attribute into a component response, which is attached
+// to the operations after all the handlers were parsed. The name of the component is the name of the structure by default.
+func (context *Context) parseSharedResponse(spec *ast.TypeSpec, documentation string) error {
+	attributes := make(commentAttributes)
+	if err := attributes.FromComments(documentation); err != nil {
+		return wrapError(err, "failed to extract attributes")
+	} else if !attributes.HasKey(SharedResponseAttribute) {
+		return nil
+	} else if err := attributes.RequiredAttributes(DescriptionAttribute); err != nil {
+		return err
+	}
+
+	items := strings.Fields(attributes[SharedResponseAttribute])
+	if len(items) == 0 || len(items) > 2 {
+		return InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SharedResponseAttribute}, Value: attributes[SharedResponseAttribute]}
+	} else if err := validateStatusCode(items[0]); err != nil {
+		return err
+	}
+
+	shared := sharedResponse{
+		statusCode: items[0],
+		name:       spec.Name.Name,
+		tags:       parseStringByQuotesAndSpaces(attributes.GetOrDefault(TagsAttribute)),
+		structure:  spec.Name.Name,
+	}
+
+	if len(items) == 2 {
+		shared.name = items[1]
+	}
+
+	for _, existing := range context.sharedResponses {
+		if existing.name == shared.name {
+			return DuplicateSharedResponseError{Name: shared.name, Structure: shared.structure, ExistingStructure: existing.structure}
+		}
+	}
+
+	obj := context.pkg.TypesInfo.Defs[spec.Name]
+	if obj == nil {
+		return TypeNotFoundError{TypeName: spec.Name.Name}
+	}
+
+	response, err := context.newResponse(obj.Type(), attributes, []string{ContentTypeJson})
+	if err != nil {
+		return err
+	}
+
+	if context.OpenAPI.Components.Responses == nil {
+		context.OpenAPI.Components.Responses = make(map[string]Response)
+	}
+
+	context.OpenAPI.Components.Responses[shared.name] = *response
+	context.sharedResponses = append(context.sharedResponses, shared)
+	return nil
+}
+
+// Attach the shared responses to the operations, responses that are declared by the handler itself are not overridden.
+func (context *Context) attachSharedResponses() {
+	for _, paths := range []map[string]*Path{context.OpenAPI.Paths, context.OpenAPI.Webhooks} {
+		for _, path := range paths {
+			for _, operation := range path.Operations() {
+				for _, shared := range context.sharedResponses {
+					if _, exists := operation.Responses[shared.statusCode]; exists || !shared.appliesTo(operation) {
+						continue
+					}
+
+					operation.AddResponse(shared.statusCode, &Response{Reference: ComponentResponsesPrefix + shared.name})
+				}
+			}
+		}
+	}
+}
+
+// Whether the shared response is attached to the operation, a response without tags is attached to all of the operations.
+func (shared sharedResponse) appliesTo(operation *Operation) bool {
+	if len(shared.tags) == 0 {
+		return true
+	}
+
+	for _, tag := range shared.tags {
+		for _, operationTag := range operation.Tags {
+			if tag == operationTag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Add the default response to the operations that don't have any response (after the shared responses were attached),
+// or fail if there is no default response, since every operation must declare at least one response.
+func (context *Context) applyDefaultResponse() error {
+	// The webhooks are identified by their names instead of their routes
+	for _, paths := range []map[string]*Path{context.OpenAPI.Paths, context.OpenAPI.Webhooks} {
+		routes := make([]string, 0, len(paths))
+		for route := range paths {
+			routes = append(routes, route)
+		}
+
+		sort.Strings(routes)
+
+		for _, route := range routes {
+			for _, method := range Methods {
+				operation, _ := paths[route].GetOperationByMethod(method)
+				if operation == nil || len(operation.Responses) > 0 {
+					continue
+				} else if context.defaultResponse == nil {
+					return MissingResponsesError{Route: route, Method: method}
+				}
+
+				operation.AddResponse(context.defaultResponse.StatusCode, &Response{Description: context.defaultResponse.Description})
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package echo_swagger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStatusCode(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, code := range []string{"200", "204", "404", "599", "1XX", "4XX", "5XX", "default"} {
+		assert.NoError(validateStatusCode(code), code)
+	}
+
+	for _, code := range []string{"", "20", "2000", "600", "099", "6XX", "4xx", "4X0", "OK", "Default"} {
+		assert.Equal(InvalidStatusCodeError{StatusCode: code}, validateStatusCode(code), code)
+	}
+}
+
+func TestDuplicateSharedResponse(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	context := NewContext()
+	if !assert.NoError(context.ParseDirectory("../testdata/shared", "./...")) {
+		return
+	}
+
+	expected := DuplicateSharedResponseError{Name: "Unauthorized", Structure: "InvalidTokenResponse", ExistingStructure: "UnauthorizedResponse"}
+	assert.Equal("shared response `Unauthorized` of `InvalidTokenResponse` is already declared by `UnauthorizedResponse`", expected.Error())
+
+	warnings := []string{}
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && strings.HasSuffix(entry.Message, expected.Error()) {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+
+	assert.Len(warnings, 1)
+	assert.Equal("The request is not authenticated", context.OpenAPI.Components.Responses["Unauthorized"].Description)
+}
@@ -0,0 +1,176 @@
+package echo_swagger
+
+import (
+	"go/types"
+
+	"github.com/fatih/structtag"
+	log "github.com/sirupsen/logrus"
+)
+
+// A field of a structure (or of one of its embedded structures) with the information
+// required to resolve the conflicts between fields with the same name.
+type structField struct {
+	field    *types.Var
+	property *Property
+
+	// The depth of the embedded structure the field was declared in, 0 for the structure itself
+	depth int
+
+	// Whether the name of the field was explicitly set by its tag
+	tagged bool
+}
+
+// Parse the fields of a structure with the semantics of encoding/json, fields of embedded structures
+// (and pointers to structures) are flattened unless they are named by their tag.
+func (context *Context) parseStructFields(t *types.Struct, tag string, depth int, embedded map[*types.Struct]bool) ([]structField, error) {
+	fields := []structField{}
+
+	// Protect against structures that embed themselves
+	embedded[t] = true
+	defer delete(embedded, t)
+
+	for fieldIndex := 0; fieldIndex < t.NumFields(); fieldIndex++ {
+		field := t.Field(fieldIndex)
+		fieldTag := t.Tag(fieldIndex)
+		tagName := tagName(fieldTag, tag)
+
+		if tagName == "-" {
+			continue
+		} else if tag == XmlTag && field.Name() == XmlNameField {
+			// The name of the element is not a part of its content
+			continue
+		}
+
+		if field.Anonymous() && tagName == "" {
+			fieldType := field.Type()
+			pointer, isPointer := fieldType.(*types.Pointer)
+			if isPointer {
+				fieldType = pointer.Elem()
+			}
+
+			if structType, ok := fieldType.Underlying().(*types.Struct); ok {
+				if !field.Exported() && isPointer {
+					// An unexported embedded pointer can't be set by encoding/json, so its fields are ignored
+					continue
+				} else if embedded[structType] {
+					continue
+				}
+
+				embeddedFields, err := context.parseStructFields(structType, tag, depth+1, embedded)
+				if err != nil {
+					return nil, wrapError(err, "failed to parse embedded field `%s`", field.Name())
+				}
+
+				fields = append(fields, embeddedFields...)
+				continue
+			}
+
+			// Embedded fields of other types are treated like regular fields named by their type
+		}
+
+		if !field.Exported() {
+			// Unexported fields are ignored by encoding/json
+			continue
+		}
+
+		attributes, err := context.objectAttributes(field)
+		if err != nil {
+			return nil, err
+		}
+
+		// The field can declare the schemas it holds by itself, e.g. an interface with `@oneOf Cat Dog`
+		fieldProperty, ok, err := context.parseComposition(attributes, field.Pkg(), tag)
+		if err == nil && !ok {
+			fieldProperty, err = context.parseProperty(field.Type(), tag)
+		}
+
+		if err != nil {
+			return nil, wrapError(err, "failed to parse field `%s`", field.Name())
+		} else if fieldProperty == nil {
+			continue
+		} else if err := fieldProperty.ParseTags(fieldTag, tag, field.Name()); err != nil {
+			return nil, wrapError(err, "failed to parse field `%s` tags", field.Name())
+		} else if fieldProperty.IgnoreProperty() {
+			continue
+		}
+
+		if !fieldProperty.IsReference() {
+			// Siblings of a reference are ignored, so the attributes are applied only on inline schemas
+			if err := fieldProperty.ParseAttributes(attributes); err != nil {
+				return nil, wrapError(err, "failed to parse field `%s` attributes", field.Name())
+			}
+		}
+
+		fields = append(fields, structField{
+			field:    field,
+			property: fieldProperty,
+			depth:    depth,
+			tagged:   tagName != "",
+		})
+	}
+
+	return fields, nil
+}
+
+// Resolve the conflicts between fields with the same name by the rules of encoding/json: the field with the
+// shallowest depth wins, and between fields of the same depth the one that is named by its tag wins.
+// If there is no single dominant field, all of the fields with the name are dropped.
+func (context *Context) dominantStructFields(fields []structField) []structField {
+	names := []string{}
+	byName := map[string][]structField{}
+
+	for _, field := range fields {
+		if _, exists := byName[field.property.Name]; !exists {
+			names = append(names, field.property.Name)
+		}
+
+		byName[field.property.Name] = append(byName[field.property.Name], field)
+	}
+
+	dominants := []structField{}
+
+	for _, name := range names {
+		candidates := byName[name]
+
+		dominant := candidates[0]
+		conflict := false
+
+		for _, candidate := range candidates[1:] {
+			if candidate.depth < dominant.depth || (candidate.depth == dominant.depth && candidate.tagged && !dominant.tagged) {
+				dominant, conflict = candidate, false
+			} else if candidate.depth == dominant.depth && candidate.tagged == dominant.tagged {
+				conflict = true
+			}
+		}
+
+		if conflict {
+			log.Warning(context.position(dominant.field.Pos()), ": field `", name, "` is declared more than once in the same depth, all of its declarations are ignored")
+			continue
+		}
+
+		for _, candidate := range candidates {
+			if candidate != dominant {
+				log.Warning(context.position(candidate.field.Pos()), ": field `", name, "` is shadowed by the field declared at ", context.position(dominant.field.Pos()))
+			}
+		}
+
+		dominants = append(dominants, dominant)
+	}
+
+	return dominants
+}
+
+// Returns the name of the field from its tag, or an empty string if the tag doesn't name the field.
+func tagName(data string, nameTag string) string {
+	tags, err := structtag.Parse(data)
+	if err != nil {
+		return ""
+	}
+
+	name, err := tags.Get(nameTag)
+	if err != nil {
+		return ""
+	}
+
+	return name.Name
+}
@@ -0,0 +1,46 @@
+package echo_swagger
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDominantStructFields(t *testing.T) {
+	assert := assert.New(t)
+
+	hook := test.NewGlobal()
+	defer hook.Reset()
+
+	context := NewContext()
+	if !assert.NoError(context.ParseDirectory("../testdata/valid", "./...")) {
+		return
+	}
+
+	path, err := filepath.Abs("../testdata/valid/embedding.go")
+	if !assert.NoError(err) {
+		return
+	}
+
+	warnings := []string{}
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && strings.HasPrefix(entry.Message, path) {
+			warnings = append(warnings, strings.ReplaceAll(entry.Message, path, "embedding.go"))
+		}
+	}
+
+	assert.Equal([]string{
+		// The field of the embedded structure is shadowed by the shallower field of the body
+		"embedding.go:5:2: field `version` is shadowed by the field declared at embedding.go:29:3",
+		// The fields of `Left` & `Right` are in the same depth and neither of them is tagged
+		"embedding.go:13:2: field `label` is declared more than once in the same depth, all of its declarations are ignored",
+	}, warnings)
+
+	body := context.OpenAPI.Paths["/documents"].Put.RequestBody.Content[ContentTypeJson].Schema
+	assert.Equal(Property{Type: PropertyType_String, Name: "version"}, body.Properties["version"])
+	assert.NotContains(body.Properties, "label")
+}
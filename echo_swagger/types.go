@@ -0,0 +1,313 @@
+package echo_swagger
+
+import (
+	"go/constant"
+	"go/types"
+	"sort"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// The well known types that have a custom JSON representation, by their fully qualified name (package path and type name).
+// Those types are checked before their underlying type is parsed.
+var wellKnownTypes = map[string]Property{
+	"time.Time":                             {Type: PropertyType_String, Format: PropertyFormat_DateTime},
+	"time.Duration":                         {Type: PropertyType_Integer, Format: PropertyFormat_Int64},
+	"encoding/json.RawMessage":              {},
+	"math/big.Int":                          {Type: PropertyType_String},
+	"math/big.Float":                        {Type: PropertyType_String},
+	"net/url.URL":                           {Type: PropertyType_String, Format: "uri"},
+	"github.com/google/uuid.UUID":           {Type: PropertyType_String, Format: "uuid"},
+	"github.com/shopspring/decimal.Decimal": {Type: PropertyType_String},
+	"database/sql.NullString":               {Type: PropertyType_String, Nullable: true},
+	"database/sql.NullBool":                 {Type: PropertyType_Boolean, Nullable: true},
+	"database/sql.NullByte":                 {Type: PropertyType_Integer, Nullable: true},
+	"database/sql.NullInt16":                {Type: PropertyType_Integer, Nullable: true},
+	"database/sql.NullInt32":                {Type: PropertyType_Integer, Format: PropertyFormat_Int32, Nullable: true},
+	"database/sql.NullInt64":                {Type: PropertyType_Integer, Format: PropertyFormat_Int64, Nullable: true},
+	"database/sql.NullFloat64":              {Type: PropertyType_Number, Format: PropertyFormat_Double, Nullable: true},
+	"database/sql.NullTime":                 {Type: PropertyType_String, Format: PropertyFormat_DateTime, Nullable: true},
+	"mime/multipart.FileHeader":             {Type: PropertyType_String, Format: PropertyFormat_Binary},
+}
+
+// Register a custom mapping between a named type and its schema, which is used instead of parsing the type itself.
+// The type name must be fully qualified by its package path, e.g. `github.com/google/uuid.UUID`.
+func (context *Context) RegisterType(typeName string, property Property) {
+	context.knownTypes[typeName] = property
+}
+
+// Returns the schema of a named type (or of an alias) if it was registered as a well known type.
+func (context *Context) knownType(obj *types.TypeName) (*Property, bool) {
+	property, exists := context.knownTypes[qualifiedObjectName(obj)]
+	if !exists {
+		return nil, false
+	}
+
+	return &property, true
+}
+
+// Returns the name of the type qualified by its package path.
+func qualifiedTypeName(named *types.Named) string {
+	return qualifiedObjectName(named.Obj())
+}
+
+func qualifiedObjectName(obj *types.TypeName) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+
+	return obj.Pkg().Path() + "." + obj.Name()
+}
+
+// Returns the schema of a named type that was overridden by its documentation, either with the
+// `@schema type=string format=date` attribute (or `ref=pkg.Type` for a component), or with the `@type` and `@format` attributes.
+func (context *Context) typeOverride(named *types.Named) (*Property, bool, error) {
+	attributes, err := context.objectAttributes(named.Obj())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if attributes.HasKey(SchemaAttribute) {
+		property := Property{}
+
+		for _, item := range parseStringByQuotesAndSpaces(attributes[SchemaAttribute]) {
+			key, value, found := strings.Cut(item, "=")
+			if !found {
+				return nil, false, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SchemaAttribute}, Value: attributes[SchemaAttribute]}
+			}
+
+			switch key {
+			case "type":
+				property.Type = PropertyType(value)
+
+			case "format":
+				property.Format = PropertyFormat(value)
+
+			case "ref":
+				if !strings.HasPrefix(value, "#/") {
+					value = ComponentSchemasPrefix + value
+				}
+
+				property.Reference = value
+
+			default:
+				return nil, false, InvalidAttributeValueError{AttributeError: AttributeError{AttributeName: SchemaAttribute}, Value: attributes[SchemaAttribute]}
+			}
+		}
+
+		return &property, true, nil
+	} else if attributes.HasKey(TypeAttribute) {
+		return &Property{
+			Type:   PropertyType(attributes[TypeAttribute]),
+			Format: PropertyFormat(attributes.GetOrDefault(FormatAttribute)),
+		}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// Returns the schema of a named type that customizes its JSON encoding by implementing json.Marshaler or encoding.TextMarshaler.
+// Types that implement encoding.TextMarshaler are encoded as strings, but the encoding of a json.Marshaler can't be known,
+// so a warning is reported and a free-form schema is used. json.Marshaler is ignored for other tags (e.g. query parameters),
+// which are described by their underlying type.
+func (context *Context) marshalerType(named *types.Named, tag string) (*Property, bool) {
+	// The method set of the pointer contains the methods of both value and pointer receivers
+	methods := types.NewMethodSet(types.NewPointer(named))
+
+	if tag == JsonTag && hasMarshalMethod(methods, "MarshalJSON") {
+		name := qualifiedTypeName(named)
+		if context.reportedTypes[name] {
+			return &Property{}, true
+		}
+
+		context.reportedTypes[name] = true
+		log.Warning(context.position(named.Obj().Pos()), ": type `", named.Obj().Name(), "` implements json.Marshaler, use the `@schema` attribute to describe its encoding")
+		return &Property{}, true
+	} else if hasMarshalMethod(methods, "MarshalText") {
+		return &Property{Type: PropertyType_String}, true
+	}
+
+	return nil, false
+}
+
+// Check whether the method set has a marshal method with the signature `func() ([]byte, error)`.
+func hasMarshalMethod(methods *types.MethodSet, name string) bool {
+	selection := methods.Lookup(nil, name)
+	if selection == nil {
+		return false
+	}
+
+	signature, ok := selection.Type().(*types.Signature)
+	if !ok || signature.Params().Len() != 0 || signature.Results().Len() != 2 {
+		return false
+	}
+
+	data, ok := signature.Results().At(0).Type().(*types.Slice)
+	if !ok {
+		return false
+	} else if elem, ok := data.Elem().(*types.Basic); !ok || elem.Kind() != types.Byte {
+		return false
+	}
+
+	return types.Identical(signature.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// Returns the schema of a named basic type that has typed constants declared in its package (e.g. `type Status string` with
+// `const StatusActive Status = "active"`). The values of the constants are used as the enum of the schema, and their
+// documentation is used as the descriptions of the values.
+func (context *Context) parseEnum(named *types.Named) (*Property, bool, error) {
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil, false, nil
+	}
+
+	scope := named.Obj().Pkg().Scope()
+	constants := []*types.Const{}
+
+	for _, name := range scope.Names() {
+		if obj, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(obj.Type(), named) {
+			constants = append(constants, obj)
+		}
+	}
+
+	if len(constants) == 0 {
+		return nil, false, nil
+	}
+
+	// Keep the order of the declarations
+	sort.SliceStable(constants, func(i, j int) bool {
+		return constants[i].Pos() < constants[j].Pos()
+	})
+
+	property := Property{}
+	property.Type, property.Format = typeAndFormatFromKind(basic.Kind())
+
+	attributes, err := context.objectAttributes(named.Obj())
+	if err != nil {
+		return nil, false, err
+	}
+
+	property.Description = attributes.GetOrDefault(DescriptionAttribute)
+	if property.Description == "" {
+		property.Description = attributes.Text()
+	}
+
+	hasDescriptions := false
+	descriptions := []string{}
+
+	for _, obj := range constants {
+		property.Enum = append(property.Enum, constantValue(obj.Val()))
+
+		attributes, err := context.objectAttributes(obj)
+		if err != nil {
+			return nil, false, err
+		}
+
+		description := attributes.GetOrDefault(DescriptionAttribute)
+		if description == "" {
+			description = attributes.Text()
+		}
+
+		hasDescriptions = hasDescriptions || description != ""
+		descriptions = append(descriptions, description)
+	}
+
+	if hasDescriptions {
+		property.EnumDescriptions = descriptions
+	}
+
+	return &property, true, nil
+}
+
+// Convert a constant value to its matching Go value.
+func constantValue(value constant.Value) interface{} {
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value)
+
+	case constant.Bool:
+		return constant.BoolVal(value)
+
+	case constant.Int:
+		if integer, exact := constant.Int64Val(value); exact {
+			return integer
+		}
+
+		return value.ExactString()
+
+	case constant.Float:
+		float, _ := constant.Float64Val(value)
+		return float
+	}
+
+	return value.ExactString()
+}
+
+// Returns the name of a named type with its type arguments (if it is an instantiated generic type) separated by underscores,
+// e.g. `Page_User` for `Page[User]` and `Envelope_Page_User` for `Envelope[Page[User]]`.
+func genericTypeName(named *types.Named) string {
+	name := named.Obj().Name()
+
+	args := named.TypeArgs()
+	for index := 0; index < args.Len(); index++ {
+		name += "_" + typeArgumentName(args.At(index))
+	}
+
+	return name
+}
+
+func typeArgumentName(t types.Type) string {
+	switch t := t.(type) {
+	case *types.Named:
+		return genericTypeName(t)
+
+	case *types.Basic:
+		return t.Name()
+
+	case *types.Pointer:
+		return typeArgumentName(t.Elem())
+
+	case *types.Slice:
+		return "List_" + typeArgumentName(t.Elem())
+
+	case *types.Array:
+		return "List_" + typeArgumentName(t.Elem())
+
+	case *types.Map:
+		return "Map_" + typeArgumentName(t.Key()) + "_" + typeArgumentName(t.Elem())
+	}
+
+	// Keep only the characters that are valid in a component name
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, types.TypeString(t, func(pkg *types.Package) string { return pkg.Name() }))
+}
+
+// Returns the single underlying type of the type parameter constraint (e.g. `string` for `~string`), or nil if there is none.
+func coreType(tparam *types.TypeParam) types.Type {
+	constraint, ok := tparam.Constraint().Underlying().(*types.Interface)
+	if !ok || constraint.NumEmbeddeds() != 1 {
+		return nil
+	}
+
+	switch embedded := constraint.EmbeddedType(0).(type) {
+	case *types.Union:
+		if embedded.Len() != 1 {
+			return nil
+		}
+
+		return embedded.Term(0).Type().Underlying()
+
+	case *types.Interface:
+		return nil
+
+	default:
+		return embedded
+	}
+}
@@ -0,0 +1,247 @@
+package echo_swagger
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newNamedType(pkgPath string, pkgName string, name string, underlying types.Type) *types.Named {
+	pkg := types.NewPackage(pkgPath, pkgName)
+	return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), underlying, nil)
+}
+
+func TestWellKnownTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	context := NewContext()
+	context.RegisterType("example.com/money.Money", Property{Type: PropertyType_String, Format: "decimal"})
+
+	opaque := types.NewStruct([]*types.Var{
+		types.NewField(token.NoPos, nil, "wall", types.Typ[types.Uint64], false),
+	}, nil)
+
+	type testCase struct {
+		t        types.Type
+		expected Property
+	}
+
+	testCases := []testCase{
+		{
+			t:        newNamedType("time", "time", "Time", opaque),
+			expected: Property{Type: PropertyType_String, Format: PropertyFormat_DateTime},
+		},
+		{
+			t:        types.NewPointer(newNamedType("math/big", "big", "Int", opaque)),
+			expected: Property{Type: PropertyType_String},
+		},
+		{
+			t:        newNamedType("database/sql", "sql", "NullString", opaque),
+			expected: Property{Type: PropertyType_String, Nullable: true},
+		},
+		{
+			t:        newNamedType("encoding/json", "json", "RawMessage", types.NewSlice(types.Typ[types.Byte])),
+			expected: Property{},
+		},
+		{
+			// Newer versions of encoding/json declare RawMessage as an alias of `jsontext.Value`
+			t:        types.NewAlias(types.NewTypeName(token.NoPos, types.NewPackage("encoding/json", "json"), "RawMessage", nil), newNamedType("encoding/json/jsontext", "jsontext", "Value", types.NewSlice(types.Typ[types.Byte]))),
+			expected: Property{},
+		},
+		{
+			t:        types.NewSlice(types.NewPointer(newNamedType("mime/multipart", "multipart", "FileHeader", opaque))),
+			expected: Property{Type: PropertyType_Array, Items: Property{Type: PropertyType_String, Format: PropertyFormat_Binary}},
+		},
+		{
+			t:        types.NewSlice(types.Typ[types.Byte]),
+			expected: Property{Type: PropertyType_String, Format: PropertyFormat_Byte},
+		},
+		{
+			t:        newNamedType("example.com/money", "money", "Money", opaque),
+			expected: Property{Type: PropertyType_String, Format: "decimal"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		property, err := context.parseProperty(testCase.t, JsonTag)
+		if assert.NoError(err, testCase.t.String()) && assert.NotNil(property, testCase.t.String()) {
+			assert.Equal(testCase.expected, *property, testCase.t.String())
+		}
+	}
+
+	assert.Empty(context.OpenAPI.Components.Schemas)
+}
+
+func TestWellKnownTypesFromSource(t *testing.T) {
+	assert := assert.New(t)
+
+	context := NewContext()
+	if !assert.NoError(context.ParseDirectory("../testdata/wellknown", "./...")) {
+		return
+	}
+
+	path, exists := context.OpenAPI.Paths["/invoices"]
+	if !assert.True(exists) || !assert.NotNil(path.Post) {
+		return
+	}
+
+	parameters := map[string]Property{}
+	for _, parameter := range path.Post.Parameters {
+		parameters[parameter.Name] = parameter.Schema.Property
+	}
+
+	assert.Equal(map[string]Property{
+		"dueAt":   {Type: PropertyType_String, Format: PropertyFormat_DateTime, Name: "dueAt"},
+		"timeout": {Type: PropertyType_Integer, Format: PropertyFormat_Int64, Name: "timeout"},
+	}, parameters)
+
+	binary := Property{Type: PropertyType_String, Format: PropertyFormat_Binary}
+	form := path.Post.RequestBody.Content[ContentTypeMultipartForm].Schema
+	assert.Equal(Property{Type: PropertyType_String, Format: PropertyFormat_Binary, Name: "receipt"}, form.Properties["receipt"])
+	assert.Equal(Property{Type: PropertyType_Array, Items: binary, Name: "attachments"}, form.Properties["attachments"])
+
+	uuid := Property{Type: PropertyType_String, Format: "uuid"}
+	dateTime := Property{Type: PropertyType_String, Format: PropertyFormat_DateTime}
+
+	expected := map[string]Property{
+		"id":        uuid,
+		"amount":    {Type: PropertyType_String},
+		"total":     {Type: PropertyType_String},
+		"issuedAt":  dateTime,
+		"paidAt":    dateTime,
+		"interval":  {Type: PropertyType_Integer, Format: PropertyFormat_Int64},
+		"link":      {Type: PropertyType_String, Format: "uri"},
+		"metadata":  {},
+		"note":      {Type: PropertyType_String, Nullable: true},
+		"reference": {Type: PropertyType_Integer, Format: PropertyFormat_Int64, Nullable: true},
+		"parent":    uuid,
+		"related":   {Type: PropertyType_Array, Items: uuid},
+		"payment":   {Type: PropertyType_String, Format: PropertyFormat_DateTime, Nullable: true},
+		"rate":      {Type: PropertyType_String},
+		"discount":  {Type: PropertyType_String},
+	}
+
+	response := path.Post.Responses["201"].Content[ContentTypeJson].Schema
+	assert.Equal(len(expected), len(response.Properties))
+
+	for name, property := range expected {
+		property.Name = name
+		assert.Equal(property, response.Properties[name], name)
+	}
+
+	assert.Empty(context.OpenAPI.Components.Schemas)
+}
+
+func TestEnums(t *testing.T) {
+	assert := assert.New(t)
+
+	pkg := types.NewPackage("example.com/orders", "orders")
+	priority := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Priority", nil), types.Typ[types.Int], nil)
+	pkg.Scope().Insert(priority.Obj())
+
+	for index, name := range []string{"PriorityLow", "PriorityHigh"} {
+		pkg.Scope().Insert(types.NewConst(token.Pos(index+1), pkg, name, priority, constant.MakeInt64(int64(index+1))))
+	}
+
+	context := NewContext()
+
+	property, err := context.parseProperty(priority, JsonTag)
+	if assert.NoError(err) && assert.NotNil(property) {
+		assert.Equal(Property{Reference: "#/components/schemas/orders.Priority"}, *property)
+	}
+
+	schema, exists := context.OpenAPI.Components.Schemas["orders.Priority"]
+	if assert.True(exists) {
+		assert.Equal(PropertyType_Integer, schema.Type)
+		assert.Equal([]interface{}{int64(1), int64(2)}, schema.Enum)
+		assert.Nil(schema.EnumDescriptions)
+	}
+
+	property, err = context.parseProperty(priority, BinderTag)
+	if assert.NoError(err) && assert.NotNil(property) {
+		assert.Equal(PropertyType_Integer, property.Type)
+		assert.Equal([]interface{}{int64(1), int64(2)}, property.Enum)
+	}
+}
+
+func TestRecursiveComponentsByTag(t *testing.T) {
+	assert := assert.New(t)
+
+	// type Node struct { Children []*Node `json:"children" xml:"child"` }
+	node := newNamedType("example.com/fx", "fx", "Node", nil)
+	children := types.NewField(token.NoPos, node.Obj().Pkg(), "Children", types.NewSlice(types.NewPointer(node)), false)
+	node.SetUnderlying(types.NewStruct([]*types.Var{children}, []string{`json:"children" xml:"child"`}))
+
+	context := NewContext()
+
+	property, err := context.parseProperty(node, JsonTag)
+	if assert.NoError(err) && assert.NotNil(property) {
+		assert.Equal(Property{Reference: "#/components/schemas/fx.Node"}, *property)
+	}
+
+	property, err = context.parseProperty(node, XmlTag)
+	if assert.NoError(err) && assert.NotNil(property) {
+		assert.Equal(Property{Reference: "#/components/schemas/fx.Node-xml"}, *property)
+	}
+
+	// The XML representation must not override the JSON representation of the type
+	assert.Equal(map[string]Schema{
+		"fx.Node": {Property: Property{
+			Type: PropertyType_Object,
+			Properties: map[string]Property{
+				"children": {Type: PropertyType_Array, Items: Property{Reference: "#/components/schemas/fx.Node"}, Name: "children"},
+			},
+			PropertiesOrder: []string{"children"},
+		}},
+		"fx.Node-xml": {Property: Property{
+			Type: PropertyType_Object,
+			Properties: map[string]Property{
+				"child": {Type: PropertyType_Array, Items: Property{Reference: "#/components/schemas/fx.Node-xml"}, Name: "child"},
+			},
+			PropertiesOrder: []string{"child"},
+		}},
+	}, context.OpenAPI.Components.Schemas)
+}
+
+func TestGenericComponentNames(t *testing.T) {
+	assert := assert.New(t)
+
+	// type Page[T any] struct { Items []T `json:"items"` }
+	pkg := types.NewPackage("example.com/api", "api")
+	page := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Page", nil), nil, nil)
+	param := types.NewTypeParam(types.NewTypeName(token.NoPos, pkg, "T", nil), types.NewInterfaceType(nil, nil))
+	page.SetTypeParams([]*types.TypeParam{param})
+	items := types.NewField(token.NoPos, pkg, "Items", types.NewSlice(param), false)
+	page.SetUnderlying(types.NewStruct([]*types.Var{items}, []string{`json:"items"`}))
+
+	context := NewContext()
+
+	// The type arguments share their name, but are declared in different packages
+	expected := []string{"api.Page_User", "example.com.api.Page_User", "example.com.api.Page_User_2"}
+	references := map[string]bool{}
+
+	for index, pkgName := range []string{"a", "b", "c"} {
+		user := newNamedType("example.com/"+pkgName, pkgName, "User", types.NewStruct(nil, nil))
+
+		instance, err := types.Instantiate(nil, page, []types.Type{user}, true)
+		if !assert.NoError(err) {
+			return
+		}
+
+		property, err := context.parseProperty(instance, JsonTag)
+		if assert.NoError(err) && assert.NotNil(property) {
+			assert.Equal(ComponentSchemasPrefix+expected[index], property.Reference)
+			references[property.Reference] = true
+		}
+
+		schema, exists := context.OpenAPI.Components.Schemas[expected[index]]
+		if assert.True(exists, expected[index]) {
+			assert.Equal(ComponentSchemasPrefix+pkgName+".User", schema.Properties["items"].Items.(Property).Reference, expected[index])
+		}
+	}
+
+	assert.Len(references, 3)
+}
@@ -0,0 +1,219 @@
+package echo_swagger
+
+import (
+	"strconv"
+	"strings"
+)
+
+// The go-playground/validator rules that are translated into a format of a property.
+var validateFormats = map[string]PropertyFormat{
+	"email":    "email",
+	"url":      "uri",
+	"uri":      "uri",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"datetime": PropertyFormat_DateTime,
+	"ip":       "ip",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"base64":   PropertyFormat_Byte,
+}
+
+// Apply the go-playground/validator rules of a field (the comma-separated items of the `validate` tag) on the property.
+// Rules that have no matching JSON schema keyword are ignored.
+func (p *Property) parseValidations(rules []string) {
+	omitEmpty := false
+
+	for index := 0; index < len(rules); index++ {
+		rule := strings.TrimSpace(rules[index])
+		if strings.Contains(rule, "|") {
+			// Or'ed rules can't be translated into a single schema, skip them
+			continue
+		}
+
+		name, param := rule, ""
+		if equalIndex := strings.Index(rule, "="); equalIndex != -1 {
+			name, param = rule[:equalIndex], rule[equalIndex+1:]
+		}
+
+		if p.IsReference() && name != ValidateRequiredValue && name != ValidateOmitEmpty {
+			// Siblings of a reference are ignored, so only the requirement of the field is relevant
+			continue
+		}
+
+		switch name {
+		case ValidateRequiredValue:
+			p.Required = true
+
+		case ValidateOmitEmpty:
+			omitEmpty = true
+
+		case ValidateDive:
+			// All the rules after the dive are applied on the items of the array or the values of the map
+			p.parseDiveValidations(rules[index+1:])
+			index = len(rules)
+
+		case "oneof":
+			p.Enum = nil
+
+			for _, item := range parseValidationParams(param) {
+				if value, err := p.parseValue(item); err == nil {
+					p.Enum = append(p.Enum, value)
+				}
+			}
+
+		case "unique":
+			if p.Type == PropertyType_Array {
+				p.UniqueItems = true
+			}
+
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			p.parseBound(name, param)
+
+		default:
+			if format, ok := validateFormats[name]; ok {
+				p.Format = format
+			}
+		}
+	}
+
+	if omitEmpty {
+		// An empty value passes the validation, so the field can't be required
+		p.Required = false
+	}
+}
+
+func (p *Property) parseDiveValidations(rules []string) {
+	var container *interface{}
+
+	switch {
+	case p.Type == PropertyType_Array:
+		container = &p.Items
+
+	case p.AdditionalProperties != nil:
+		container = &p.AdditionalProperties
+
+		// Skip the rules of the map keys, they have no matching schema
+		for index, rule := range rules {
+			if rule == ValidateKeys {
+				for end := index; end < len(rules); end++ {
+					if rules[end] == ValidateEndKeys {
+						rules = append(rules[:index:index], rules[end+1:]...)
+						break
+					}
+				}
+
+				break
+			}
+		}
+
+	default:
+		return
+	}
+
+	items, ok := (*container).(Property)
+	if !ok || items.IsReference() {
+		return
+	}
+
+	items.parseValidations(rules)
+
+	// The required rule of the items means that they are non zero values, which has no meaning in the schema
+	items.Required = false
+	*container = items
+}
+
+// Apply a bound rule (min, max, len, gt, gte, lt, lte) by the type of the property.
+func (p *Property) parseBound(name string, param string) {
+	switch p.Type {
+	case PropertyType_Integer, PropertyType_Number:
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+
+		switch name {
+		case "min", "gte":
+			p.Minimum = &value
+
+		case "gt":
+			p.Minimum = &value
+			p.ExclusiveMinimum = true
+
+		case "max", "lte":
+			p.Maximum = &value
+
+		case "lt":
+			p.Maximum = &value
+			p.ExclusiveMaximum = true
+
+		case "len":
+			p.Minimum = &value
+			p.Maximum = &value
+		}
+
+	case PropertyType_String:
+		p.MinLength, p.MaxLength = parseLengthBound(name, param, p.MinLength, p.MaxLength)
+
+	case PropertyType_Array:
+		p.MinItems, p.MaxItems = parseLengthBound(name, param, p.MinItems, p.MaxItems)
+
+	case PropertyType_Object:
+		p.MinProperties, p.MaxProperties = parseLengthBound(name, param, p.MinProperties, p.MaxProperties)
+	}
+}
+
+// Translate a bound rule on a length (of a string, array or map) into the minimum and maximum lengths.
+func parseLengthBound(name string, param string, minimum *uint64, maximum *uint64) (*uint64, *uint64) {
+	value, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return minimum, maximum
+	}
+
+	switch name {
+	case "min", "gte":
+		return &value, maximum
+
+	case "gt":
+		value++
+		return &value, maximum
+
+	case "max", "lte":
+		return minimum, &value
+
+	case "lt":
+		if value == 0 {
+			return minimum, maximum
+		}
+
+		value--
+		return minimum, &value
+
+	case "len":
+		return &value, &value
+	}
+
+	return minimum, maximum
+}
+
+// Split the parameters of a rule by spaces, values with spaces can be wrapped with single quotes.
+func parseValidationParams(param string) []string {
+	quoted := false
+
+	params := strings.FieldsFunc(param, func(r rune) bool {
+		if r == '\'' {
+			quoted = !quoted
+		}
+
+		return !quoted && r == ' '
+	})
+
+	for index, value := range params {
+		params[index] = strings.Trim(value, "'")
+	}
+
+	return params
+}
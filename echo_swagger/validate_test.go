@@ -0,0 +1,88 @@
+package echo_swagger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidations(t *testing.T) {
+	assert := assert.New(t)
+
+	float := func(value float64) *float64 { return &value }
+	length := func(value uint64) *uint64 { return &value }
+
+	type testCase struct {
+		tag      string
+		property Property
+		expected Property
+	}
+
+	testCases := []testCase{
+		{
+			tag:      `validate:"min=1,required"`,
+			property: Property{Type: PropertyType_String},
+			expected: Property{Type: PropertyType_String, Required: true, MinLength: length(1)},
+		},
+		{
+			tag:      `validate:"omitempty,max=10"`,
+			property: Property{Type: PropertyType_String},
+			expected: Property{Type: PropertyType_String, MaxLength: length(10)},
+		},
+		{
+			tag:      `json:",omitempty" validate:"required,max=10"`,
+			property: Property{Type: PropertyType_String},
+			expected: Property{Type: PropertyType_String, Required: true, MaxLength: length(10)},
+		},
+		{
+			tag:      `validate:"gt=0,lte=100"`,
+			property: Property{Type: PropertyType_Integer},
+			expected: Property{Type: PropertyType_Integer, Minimum: float(0), ExclusiveMinimum: true, Maximum: float(100)},
+		},
+		{
+			tag:      `validate:"len=3"`,
+			property: Property{Type: PropertyType_Array, Items: Property{Type: PropertyType_Integer}},
+			expected: Property{Type: PropertyType_Array, Items: Property{Type: PropertyType_Integer}, MinItems: length(3), MaxItems: length(3)},
+		},
+		{
+			tag:      `validate:"required,oneof=active 'not active'"`,
+			property: Property{Type: PropertyType_String},
+			expected: Property{Type: PropertyType_String, Required: true, Enum: []interface{}{"active", "not active"}},
+		},
+		{
+			tag:      `validate:"oneof=1 2 3"`,
+			property: Property{Type: PropertyType_Integer},
+			expected: Property{Type: PropertyType_Integer, Enum: []interface{}{int64(1), int64(2), int64(3)}},
+		},
+		{
+			tag:      `validate:"email"`,
+			property: Property{Type: PropertyType_String},
+			expected: Property{Type: PropertyType_String, Format: "email"},
+		},
+		{
+			tag:      `validate:"required,min=1,dive,uuid,max=36"`,
+			property: Property{Type: PropertyType_Array, Items: Property{Type: PropertyType_String}},
+			expected: Property{Type: PropertyType_Array, Required: true, MinItems: length(1), Items: Property{Type: PropertyType_String, Format: "uuid", MaxLength: length(36)}},
+		},
+		{
+			tag:      `validate:"dive,keys,min=2,endkeys,gte=0"`,
+			property: Property{Type: PropertyType_Map, AdditionalProperties: Property{Type: PropertyType_Number}},
+			expected: Property{Type: PropertyType_Map, AdditionalProperties: Property{Type: PropertyType_Number, Minimum: float(0)}},
+		},
+		{
+			tag:      `validate:"rgb|rgba"`,
+			property: Property{Type: PropertyType_String},
+			expected: Property{Type: PropertyType_String},
+		},
+	}
+
+	for _, testCase := range testCases {
+		property := testCase.property
+		if !assert.NoError(property.ParseTags(testCase.tag, JsonTag, "field"), testCase.tag) {
+			continue
+		}
+
+		testCase.expected.Name = "field"
+		assert.Equal(testCase.expected, property, testCase.tag)
+	}
+}
@@ -1,19 +1,20 @@
 module github.com/avivatedgi/echo-swagger
 
-go 1.18
+go 1.23.0
 
 require (
 	github.com/fatih/structtag v1.2.0
 	github.com/sirupsen/logrus v1.8.1
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/mod v0.26.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
-require golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
+require golang.org/x/sync v0.16.0 // indirect
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/sys v0.0.0-20220704084225-05e143d24a9e // indirect
-	golang.org/x/tools v0.1.11
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/tools v0.35.0
 )
@@ -2,10 +2,12 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"os"
 
 	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	"github.com/avivatedgi/echo-swagger/swagger2"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
@@ -13,24 +15,32 @@ import (
 func main() {
 	log.SetLevel(log.DebugLevel)
 
+	// Compare two specifications instead of generating them, e.g. `echo-swagger diff old.yaml new.yaml`
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+
 	var infoFile *os.File = nil
 
-	output := os.Stdout
+	// The output file is opened only when the specifications are written, so a failing run (or a check) never truncates it
+	outputPath := ""
 
 	directory := flag.String("dir", "", "Directory to scan for request handlers")
 	pattern := flag.String("pattern", "./...", "Package pattern to scan for request handlers")
+	discover := flag.Bool("discover", false, "Discover the routes of the handlers from their registration on echo")
+	format := flag.String("format", "", "Format of the generated OpenAPI specifications, `json` or yaml (default: inferred from the output file extension)")
+	openapiVersion := flag.String("openapi-version", "3.0", "OpenAPI `version` of the generated specifications, 3.0 or 3.1")
+	target := flag.String("target", "openapi", "Target `specification` of the generated document, openapi or swagger2 (converted from OpenAPI 3.0)")
+	check := flag.String("check", "", "`path` to the committed specifications, fails whenever they differ from the generated specifications instead of writing them")
+	handlers := flag.String("handlers", string(echo_swagger.HandlerDetectionSuffix), "How to detect the request handlers as `mode`: suffix (structures that end with Request) or interface")
+	handlerInterface := flag.String("handler-interface", "", "Fully qualified `name` of the interface that the request handlers implement when detected by interface (default: Handle(echo.Context) error)")
 
 	flag.Func("out", "Path to file output to write in the geerated OpenAPI specifications", func(s string) error {
-		if s == "-" {
-			return nil
-		}
-
-		f, err := os.OpenFile(s, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
-		if err != nil {
-			return err
+		if s != "-" {
+			outputPath = s
 		}
 
-		output = f
 		return nil
 	})
 
@@ -54,6 +64,26 @@ func main() {
 		log.Fatal("directory is required!")
 	} else if pattern == nil || *pattern == "" {
 		log.Fatal("pattern is required!")
+	} else if *check != "" && outputPath != "" {
+		log.Fatal("--out can't be used with --check, the checked specifications are never written")
+	}
+
+	version, err := echo_swagger.ParseOpenApiVersion(*openapiVersion)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *target != "openapi" && *target != "swagger2" {
+		log.Fatal("invalid target `", *target, "`, expected `openapi` or `swagger2`")
+	} else if *target == "swagger2" && version != echo_swagger.OpenApiVersion {
+		log.Fatal("swagger2 target is converted from OpenAPI 3.0, it can't be used with OpenAPI ", version)
+	}
+
+	outputFormat := echo_swagger.Format(*format)
+	if outputFormat == "" {
+		outputFormat = echo_swagger.FormatFromPath(outputPath)
+	} else if err := outputFormat.Validate(); err != nil {
+		log.Fatal(err)
 	}
 
 	// Read the info file
@@ -63,16 +93,21 @@ func main() {
 	}
 
 	// Unmarshal the info file
-	info := echo_swagger.Info{}
-	if err := yaml.Unmarshal(infoData, &info); err != nil {
+	config := echo_swagger.Config{}
+	if err := yaml.Unmarshal(infoData, &config); err != nil {
 		log.Fatal("Failed to unmarshal info file, error = ", err)
 	}
 
-	if output != os.Stdout {
-		defer output.Close()
-	}
-
 	parser := echo_swagger.NewContext()
+	parser.OpenAPI.OpenAPI = version
+	parser.DiscoverRoutes = *discover
+	parser.HandlerDetection = echo_swagger.HandlerDetection(*handlers)
+	parser.HandlerInterface = *handlerInterface
+
+	// Apply the configuration before parsing, so the security requirements of the handlers can be validated
+	if err := parser.ApplyConfig(config); err != nil {
+		log.Fatal("Invalid info file, error = ", err)
+	}
 
 	// Parse the directory
 	err = parser.ParseDirectory(*directory, *pattern)
@@ -80,15 +115,57 @@ func main() {
 		log.Fatal("Failed to parse directory ", directory, ", error = ", err)
 	}
 
-	parser.OpenAPI.Info = info
+	// Marshal the generated OpenAPI specifications, or the Swagger 2.0 specifications that are converted from them
+	var data []byte
+	if *target == "swagger2" {
+		swagger, warnings := swagger2.Convert(parser.OpenAPI)
+		for _, warning := range warnings {
+			log.Warning("swagger2: ", warning)
+		}
+
+		data, err = swagger.Marshal(outputFormat)
+	} else {
+		data, err = parser.OpenAPI.Marshal(outputFormat)
+	}
 
-	// Marshal the generated OpenAPI specifications
-	data, err := yaml.Marshal(parser.OpenAPI)
 	if err != nil {
 		log.Fatal("Failed to marshal generated OpenAPI specifications: ", err)
 	}
 
+	// Compare the generated OpenAPI specifications with the committed ones, instead of writing them
+	if *check != "" {
+		existing, err := ioutil.ReadFile(*check)
+		if err != nil {
+			log.Fatal("Failed to read the specifications to check `", *check, "`, error = ", err)
+		}
+
+		differences, err := echo_swagger.CompareDocuments(existing, data)
+		if err != nil {
+			log.Fatal("Failed to compare the specifications with `", *check, "`, error = ", err)
+		} else if len(differences) == 0 {
+			log.Info(*check, " is up to date")
+			return
+		}
+
+		fmt.Fprintf(os.Stderr, "%s is stale, %d differences from the generated specifications (- removed, + added, ~ changed):\n", *check, len(differences))
+		for _, difference := range differences {
+			fmt.Fprintln(os.Stderr, "  ", difference)
+		}
+
+		os.Exit(1)
+	}
+
 	// Write the OpenAPI specifications to the output file
+	output := os.Stdout
+	if outputPath != "" {
+		output, err = os.OpenFile(outputPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			log.Fatal("Failed to open output file ", outputPath, ", error = ", err)
+		}
+
+		defer output.Close()
+	}
+
 	if _, err := output.Write(data); err != nil {
 		log.Fatal("Failed to write generated OpenAPI specifications to file ", output.Name(), ", error = ", err)
 	}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The tests run the command by executing the test binary again with this variable, which runs `main` instead of the tests
+const runMainVariable = "ECHO_SWAGGER_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainVariable) != "" {
+		main()
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func runCommand(arguments ...string) (string, error) {
+	command := exec.Command(os.Args[0], arguments...)
+	command.Env = append(os.Environ(), runMainVariable+"=1")
+
+	output, err := command.CombinedOutput()
+	return string(output), err
+}
+
+func TestCheckWithOutput(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	arguments := []string{"--dir", "testdata/valid", "--info", "testdata/info.yaml"}
+
+	// Generate the committed specifications
+	output, err := runCommand(append(arguments, "--out", path)...)
+	if !assert.NoError(err, output) {
+		return
+	}
+
+	committed, err := os.ReadFile(path)
+	if !assert.NoError(err) || !assert.NotEmpty(committed) {
+		return
+	}
+
+	// The output can't be used with the check, and the committed specifications must stay intact
+	output, err = runCommand(append(arguments, "--out", path, "--check", path)...)
+	assert.Error(err, output)
+	assert.Contains(output, "--out can't be used with --check")
+
+	actual, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Equal(string(committed), string(actual))
+
+	output, err = runCommand(append(arguments, "--check", path)...)
+	assert.NoError(err, output)
+	assert.Contains(output, "is up to date")
+}
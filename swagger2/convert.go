@@ -0,0 +1,670 @@
+package swagger2
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+)
+
+const (
+	DefinitionsPrefix = "#/definitions/"
+	ResponsesPrefix   = "#/responses/"
+
+	// The name of the body parameter, which is not a part of the request
+	BodyParameterName = "body"
+)
+
+// The prefixes of the references in OpenAPI 3.0 by the prefixes that replace them in Swagger 2.0
+var referencePrefixes = map[string]string{
+	echo_swagger.ComponentSchemasPrefix:   DefinitionsPrefix,
+	echo_swagger.ComponentResponsesPrefix: ResponsesPrefix,
+}
+
+type converter struct {
+	warnings []string
+
+	// The security schemes that couldn't be converted, the requirements of them are dropped as well
+	unsupportedSchemes map[string]bool
+}
+
+// Convert the OpenAPI 3.0 specifications into Swagger 2.0 specifications. The constructs that can't be described
+// by Swagger 2.0 are dropped (or described approximately), and a warning is returned for each of them.
+func Convert(openapi *echo_swagger.OpenAPI) (*Swagger, []string) {
+	c := &converter{warnings: []string{}, unsupportedSchemes: map[string]bool{}}
+
+	swagger := &Swagger{
+		Swagger:      SwaggerVersion,
+		Info:         openapi.Info,
+		Paths:        map[string]*Path{},
+		Tags:         openapi.Tags,
+		ExternalDocs: openapi.ExternalDocs,
+	}
+
+	c.convertServers(swagger, openapi.Servers)
+
+	// The security schemes are converted first, so the requirements of the unsupported schemes are known
+	swagger.SecurityDefinitions = c.convertSecuritySchemes(openapi.Components.SecuritySchemes)
+	swagger.Security = c.convertSecurity("security", openapi.Security)
+	if len(openapi.Security) > 0 && swagger.Security == nil {
+		c.warn("security", "all of the security requirements use unsupported security schemes and are dropped")
+	}
+
+	if len(openapi.Components.Schemas) > 0 {
+		swagger.Definitions = map[string]Schema{}
+	}
+
+	for _, name := range sortedKeys(openapi.Components.Schemas) {
+		schema := openapi.Components.Schemas[name]
+
+		definition := c.convertSchema("definition `"+name+"`", &schema.Property)
+		definition.ExternalDocs = schema.ExternalDocumentation
+		swagger.Definitions[name] = *definition
+	}
+
+	if len(openapi.Components.Responses) > 0 {
+		swagger.Responses = map[string]Response{}
+	}
+
+	for _, name := range sortedKeys(openapi.Components.Responses) {
+		swagger.Responses[name], _ = c.convertResponse("response `"+name+"`", openapi.Components.Responses[name])
+	}
+
+	for _, route := range sortedKeys(openapi.Paths) {
+		swagger.Paths[route] = c.convertPath(route, openapi.Paths[route])
+	}
+
+	if len(openapi.Webhooks) > 0 {
+		c.warn("webhooks", "webhooks are not supported, %d webhooks are dropped", len(openapi.Webhooks))
+	}
+
+	unsupportedComponents := map[string]int{
+		"parameters":    len(openapi.Components.Parameters),
+		"requestBodies": len(openapi.Components.RequestBodies),
+		"headers":       len(openapi.Components.Headers),
+		"examples":      len(openapi.Components.Examples),
+		"links":         len(openapi.Components.Links),
+		"callbacks":     len(openapi.Components.Callbacks),
+	}
+
+	for _, name := range sortedKeys(unsupportedComponents) {
+		if unsupportedComponents[name] > 0 {
+			c.warn("components", "the `%s` components are not supported and are dropped", name)
+		}
+	}
+
+	return swagger, c.warnings
+}
+
+func (c *converter) warn(location string, message string, args ...interface{}) {
+	c.warnings = append(c.warnings, location+": "+fmt.Sprintf(message, args...))
+}
+
+// Convert the servers into the host, base path & schemes of the specifications. Swagger 2.0 supports only a single
+// host & base path, so only the servers that match the first server are converted.
+func (c *converter) convertServers(swagger *Swagger, servers []echo_swagger.Server) {
+	converted := false
+
+	for _, server := range servers {
+		// The variables of the server are replaced with their default values
+		rawUrl := server.URL
+		for _, name := range sortedKeys(server.Variables) {
+			rawUrl = strings.ReplaceAll(rawUrl, "{"+name+"}", server.Variables[name].Default)
+		}
+
+		serverUrl, err := url.Parse(rawUrl)
+		if err != nil {
+			c.warn("servers", "invalid server url `%s`: %v", server.URL, err)
+			continue
+		}
+
+		basePath := serverUrl.Path
+		if len(basePath) > 1 {
+			basePath = strings.TrimSuffix(basePath, "/")
+		}
+
+		if !converted {
+			swagger.Host, swagger.BasePath, converted = serverUrl.Host, basePath, true
+		} else if serverUrl.Host != swagger.Host || basePath != swagger.BasePath {
+			c.warn("servers", "server `%s` is dropped, only a single host & base path are supported", server.URL)
+			continue
+		}
+
+		if serverUrl.Scheme != "" && !contains(swagger.Schemes, serverUrl.Scheme) {
+			swagger.Schemes = append(swagger.Schemes, serverUrl.Scheme)
+		}
+	}
+}
+
+// Convert the security schemes into security definitions, the schemes that Swagger 2.0 doesn't support are dropped.
+func (c *converter) convertSecuritySchemes(schemes map[string]echo_swagger.SecurityScheme) map[string]SecurityScheme {
+	if len(schemes) == 0 {
+		return nil
+	}
+
+	definitions := map[string]SecurityScheme{}
+
+	for _, name := range sortedKeys(schemes) {
+		scheme := schemes[name]
+		location := "security scheme `" + name + "`"
+
+		switch {
+		case scheme.Type == echo_swagger.SecuritySchemeTypeApiKey && scheme.In != echo_swagger.ParameterLocationCookie:
+			definitions[name] = SecurityScheme{Type: "apiKey", Description: scheme.Description, Name: scheme.Name, In: string(scheme.In)}
+
+		case scheme.Type == echo_swagger.SecuritySchemeTypeHttp && strings.EqualFold(scheme.Scheme, "basic"):
+			definitions[name] = SecurityScheme{Type: "basic", Description: scheme.Description}
+
+		case scheme.Type == echo_swagger.SecuritySchemeTypeHttp && strings.EqualFold(scheme.Scheme, "bearer"):
+			c.warn(location, "bearer authentication is described as an API key in the `Authorization` header")
+			definitions[name] = SecurityScheme{Type: "apiKey", Description: scheme.Description, Name: "Authorization", In: "header"}
+
+		case scheme.Type == echo_swagger.SecuritySchemeTypeOAuth2:
+			definitions[name] = c.convertOAuthFlows(location, scheme)
+
+		default:
+			c.warn(location, "security scheme of type `%s` is not supported, the requirements of it are dropped", describeScheme(scheme))
+			c.unsupportedSchemes[name] = true
+		}
+	}
+
+	return definitions
+}
+
+// Convert the flows of an OAuth2 security scheme, Swagger 2.0 supports only a single flow for every scheme.
+func (c *converter) convertOAuthFlows(location string, scheme echo_swagger.SecurityScheme) SecurityScheme {
+	flows := []struct {
+		name string
+		flow *echo_swagger.OAuthFlow
+	}{
+		{"implicit", scheme.Flows.Implicit},
+		{"password", scheme.Flows.Password},
+		{"application", scheme.Flows.ClientCredentials},
+		{"accessCode", scheme.Flows.AuthorizationCode},
+	}
+
+	definition := SecurityScheme{Type: "oauth2", Description: scheme.Description}
+
+	for _, flow := range flows {
+		if flow.flow == nil {
+			continue
+		} else if definition.Flow != "" {
+			c.warn(location, "the `%s` flow is dropped, only a single flow is supported", flow.name)
+			continue
+		}
+
+		scopes := flow.flow.Scopes
+		if scopes == nil {
+			scopes = map[string]string{}
+		}
+
+		definition.Flow = flow.name
+		definition.AuthorizationUrl = flow.flow.AuthorizationUrl
+		definition.TokenUrl = flow.flow.TokenUrl
+		definition.Scopes = &scopes
+	}
+
+	return definition
+}
+
+func describeScheme(scheme echo_swagger.SecurityScheme) string {
+	switch scheme.Type {
+	case echo_swagger.SecuritySchemeTypeHttp:
+		return fmt.Sprintf("%s %s", scheme.Type, scheme.Scheme)
+
+	case echo_swagger.SecuritySchemeTypeApiKey:
+		return fmt.Sprintf("%s in %s", scheme.Type, scheme.In)
+	}
+
+	return string(scheme.Type)
+}
+
+// Convert the security requirements, the requirements of the unsupported security schemes are dropped.
+// Whenever all of the requirements are dropped nil is returned (and the callers warn about it), since an empty list would mean
+// that no security is required.
+func (c *converter) convertSecurity(location string, requirements []echo_swagger.SecurityRequirement) []echo_swagger.SecurityRequirement {
+	if requirements == nil {
+		return nil
+	}
+
+	converted := []echo_swagger.SecurityRequirement{}
+
+	for _, requirement := range requirements {
+		supported := true
+		for name := range requirement {
+			supported = supported && !c.unsupportedSchemes[name]
+		}
+
+		if supported {
+			converted = append(converted, requirement)
+		}
+	}
+
+	if len(requirements) > 0 && len(converted) == 0 {
+		return nil
+	}
+
+	return converted
+}
+
+func (c *converter) convertPath(route string, path *echo_swagger.Path) *Path {
+	if path.Reference != "" {
+		c.warn(route, "path references are not supported, the reference `%s` is dropped", path.Reference)
+	}
+
+	if len(path.Servers) > 0 {
+		c.warn(route, "the servers of paths are not supported and are dropped")
+	}
+
+	converted := &Path{Parameters: c.convertParameters(route, path.Parameters)}
+
+	for _, method := range echo_swagger.Methods {
+		operation, _ := path.GetOperationByMethod(method)
+		if operation == nil {
+			continue
+		}
+
+		location := method + " " + route
+		if !converted.SetOperationByMethod(method, c.convertOperation(location, operation)) {
+			c.warn(location, "the method `%s` is not supported, the operation is dropped", method)
+		}
+	}
+
+	return converted
+}
+
+func (c *converter) convertOperation(location string, operation *echo_swagger.Operation) *Operation {
+	converted := &Operation{
+		Tags:         operation.Tags,
+		Summary:      operation.Summary,
+		Description:  operation.Description,
+		ExternalDocs: operation.ExternalDocs,
+		OperationId:  operation.OperationId,
+		Deprecated:   operation.Deprecated,
+		Responses:    map[string]Response{},
+	}
+
+	if operation.Security != nil {
+		if security := c.convertSecurity(location, *operation.Security); security != nil {
+			converted.Security = &security
+		} else if len(*operation.Security) > 0 {
+			// The operation can't be made public, so it inherits the global security requirements instead
+			c.warn(location, "all of the security requirements use unsupported security schemes and are dropped, the operation falls back to the global security requirements")
+		}
+	}
+
+	if len(operation.Callbacks) > 0 {
+		c.warn(location, "callbacks are not supported and are dropped")
+	}
+
+	if len(operation.Servers) > 0 {
+		c.warn(location, "the servers of operations are not supported and are dropped")
+	}
+
+	converted.Parameters = c.convertParameters(location, operation.Parameters)
+
+	consumes, parameters := c.convertRequestBody(location, operation.RequestBody)
+	converted.Consumes = consumes
+	converted.Parameters = append(converted.Parameters, parameters...)
+
+	for _, statusCode := range sortedKeys(operation.Responses) {
+		response, produces := c.convertResponse(location+" response `"+statusCode+"`", operation.Responses[statusCode])
+		converted.Responses[statusCode] = response
+
+		for _, contentType := range produces {
+			if !contains(converted.Produces, contentType) {
+				converted.Produces = append(converted.Produces, contentType)
+			}
+		}
+	}
+
+	sort.Strings(converted.Produces)
+	return converted
+}
+
+// Convert the (non-body) parameters, the cookie parameters and the parameters that aren't primitives (or arrays of them) are dropped.
+func (c *converter) convertParameters(location string, parameters []echo_swagger.Parameter) []Parameter {
+	converted := []Parameter{}
+
+	for _, parameter := range parameters {
+		parameterLocation := fmt.Sprintf("%s %s parameter `%s`", location, parameter.In, parameter.Name)
+
+		if parameter.Reference != "" {
+			c.warn(location, "parameter references are not supported, the reference `%s` is dropped", parameter.Reference)
+			continue
+		} else if parameter.In == echo_swagger.ParameterLocationCookie {
+			c.warn(parameterLocation, "cookie parameters are not supported, the parameter is dropped")
+			continue
+		} else if parameter.Deprecated {
+			c.warn(parameterLocation, "deprecated parameters are not supported, the parameter is described as a regular parameter")
+		}
+
+		// The arrays of query parameters are exploded by default (e.g. `?id=1&id=2`)
+		collectionFormat := ""
+		if parameter.In == echo_swagger.ParameterLocationQuery {
+			collectionFormat = "multi"
+		}
+
+		items, ok := c.convertItems(parameterLocation, &parameter.Schema.Property, collectionFormat)
+		if !ok {
+			continue
+		}
+
+		converted = append(converted, Parameter{
+			Name:            parameter.Name,
+			In:              string(parameter.In),
+			Description:     parameter.Description,
+			Required:        parameter.Required,
+			AllowEmptyValue: parameter.AllowEmptyValue,
+			Items:           *items,
+		})
+	}
+
+	return converted
+}
+
+// Convert the schema of a non-body parameter (or a header), which must be a primitive or an array of primitives.
+func (c *converter) convertItems(location string, property *echo_swagger.Property, collectionFormat string) (*Items, bool) {
+	if property.Reference != "" || property.Type == "" || property.Type == echo_swagger.PropertyType_Object {
+		c.warn(location, "only primitives and arrays can be described outside of a body, the value is dropped")
+		return nil, false
+	}
+
+	items := &Items{
+		Type:             string(property.Type),
+		Format:           property.Format,
+		Default:          property.Default,
+		Enum:             property.Enum,
+		Minimum:          property.Minimum,
+		ExclusiveMinimum: property.ExclusiveMinimum,
+		Maximum:          property.Maximum,
+		ExclusiveMaximum: property.ExclusiveMaximum,
+		MinLength:        property.MinLength,
+		MaxLength:        property.MaxLength,
+		MinItems:         property.MinItems,
+		MaxItems:         property.MaxItems,
+		UniqueItems:      property.UniqueItems,
+	}
+
+	if property.Type == echo_swagger.PropertyType_Array {
+		element, ok := propertyOf(property.Items)
+		if !ok {
+			c.warn(location, "an array without items can't be described outside of a body, the value is dropped")
+			return nil, false
+		}
+
+		if items.Items, ok = c.convertItems(location, element, ""); !ok {
+			return nil, false
+		}
+
+		items.CollectionFormat = collectionFormat
+	}
+
+	return items, true
+}
+
+// Convert the request body into a body parameter, or into form parameters for the form content types.
+func (c *converter) convertRequestBody(location string, body echo_swagger.RequestBody) ([]string, []Parameter) {
+	if body.Reference != "" {
+		c.warn(location, "request body references are not supported, the reference `%s` is dropped", body.Reference)
+		return nil, nil
+	}
+
+	forms, others := []string{}, []string{}
+	for _, contentType := range sortedKeys(body.Content) {
+		if contentType == echo_swagger.ContentTypeMultipartForm || contentType == echo_swagger.ContentTypeUrlEncodedForm {
+			forms = append(forms, contentType)
+		} else {
+			others = append(others, contentType)
+		}
+	}
+
+	if len(others) > 0 {
+		if len(forms) > 0 {
+			c.warn(location, "a body can't be consumed together with forms, the content types `%s` are dropped", strings.Join(forms, "`, `"))
+		}
+
+		contentType := c.preferredContentType(location+" request body", body.Content, others)
+		schema := body.Content[contentType].Schema
+
+		return others, []Parameter{{
+			Name:        BodyParameterName,
+			In:          "body",
+			Description: body.Description,
+			Required:    body.Required,
+			Schema:      c.convertSchema(location+" request body", &schema.Property),
+		}}
+	} else if len(forms) == 0 {
+		return nil, nil
+	}
+
+	contentType := c.preferredContentType(location+" request body", body.Content, forms)
+	form := body.Content[contentType].Schema.Property
+
+	if form.Type != echo_swagger.PropertyType_Object {
+		c.warn(location, "only inline objects can be described as forms, the request body is dropped")
+		return forms, nil
+	}
+
+	parameters := []Parameter{}
+
+	for _, name := range sortedKeys(form.Properties) {
+		field := form.Properties[name]
+		fieldLocation := location + " form field `" + name + "`"
+
+		var items *Items
+		if field.Type == echo_swagger.PropertyType_String && field.Format == echo_swagger.PropertyFormat_Binary {
+			items = &Items{Type: "file"}
+		} else {
+			var ok bool
+			if items, ok = c.convertItems(fieldLocation, &field, "multi"); !ok {
+				continue
+			} else if items.Items != nil && items.Items.Format == echo_swagger.PropertyFormat_Binary {
+				c.warn(fieldLocation, "multiple files can't be described, the field is dropped")
+				continue
+			}
+		}
+
+		parameters = append(parameters, Parameter{
+			Name:        name,
+			In:          "formData",
+			Description: field.Description,
+			Required:    contains(form.RequiredProperties, name),
+			Items:       *items,
+		})
+	}
+
+	return forms, parameters
+}
+
+// Convert a response, returns the content types that the response produces as well.
+func (c *converter) convertResponse(location string, response echo_swagger.Response) (Response, []string) {
+	if response.Reference != "" {
+		return Response{Reference: c.convertReference(location, response.Reference)}, nil
+	}
+
+	converted := Response{Description: response.Description}
+
+	if len(response.Links) > 0 {
+		c.warn(location, "links are not supported and are dropped")
+	}
+
+	for _, name := range sortedKeys(response.Headers) {
+		header := response.Headers[name]
+		if header.Reference != "" {
+			c.warn(location, "header references are not supported, the reference `%s` is dropped", header.Reference)
+			continue
+		}
+
+		items, ok := c.convertItems(location+" header `"+name+"`", &header.Schema.Property, "")
+		if !ok {
+			continue
+		}
+
+		if converted.Headers == nil {
+			converted.Headers = map[string]Header{}
+		}
+
+		converted.Headers[name] = Header{Description: header.Description, Items: *items}
+	}
+
+	contentTypes := sortedKeys(response.Content)
+	if len(contentTypes) == 0 {
+		return converted, nil
+	}
+
+	contentType := c.preferredContentType(location, response.Content, contentTypes)
+	schema := response.Content[contentType].Schema
+
+	converted.Schema = c.convertSchema(location, &schema.Property)
+
+	// Binary responses are described as files
+	if converted.Schema.Type == echo_swagger.PropertyType_String && converted.Schema.Format == echo_swagger.PropertyFormat_Binary {
+		converted.Schema.Type, converted.Schema.Format = "file", echo_swagger.PropertyFormat_None
+	}
+
+	return converted, contentTypes
+}
+
+// Returns the content type whose schema is converted, Swagger 2.0 supports only a single schema for all the content types.
+func (c *converter) preferredContentType(location string, content map[string]echo_swagger.MediaType, contentTypes []string) string {
+	preferred := contentTypes[0]
+	if contains(contentTypes, echo_swagger.ContentTypeJson) {
+		preferred = echo_swagger.ContentTypeJson
+	}
+
+	for _, contentType := range contentTypes {
+		if !reflect.DeepEqual(content[contentType].Schema, content[preferred].Schema) {
+			c.warn(location, "the schemas of the content types differ, only the schema of `%s` is converted", preferred)
+			break
+		}
+	}
+
+	return preferred
+}
+
+func (c *converter) convertSchema(location string, property *echo_swagger.Property) *Schema {
+	schema := &Schema{
+		Reference:        c.convertReference(location, property.Reference),
+		Type:             property.Type,
+		Format:           property.Format,
+		Description:      property.Description,
+		Required:         property.RequiredProperties,
+		PropertiesOrder:  property.PropertiesOrder,
+		ReadOnly:         property.ReadOnly,
+		XML:              property.XML,
+		Example:          property.Example,
+		Default:          property.Default,
+		Enum:             property.Enum,
+		Minimum:          property.Minimum,
+		ExclusiveMinimum: property.ExclusiveMinimum,
+		Maximum:          property.Maximum,
+		ExclusiveMaximum: property.ExclusiveMaximum,
+		MinLength:        property.MinLength,
+		MaxLength:        property.MaxLength,
+		MinItems:         property.MinItems,
+		MaxItems:         property.MaxItems,
+		UniqueItems:      property.UniqueItems,
+		MinProperties:    property.MinProperties,
+		MaxProperties:    property.MaxProperties,
+		Nullable:         property.Nullable,
+		EnumDescriptions: property.EnumDescriptions,
+	}
+
+	if len(property.Properties) > 0 {
+		schema.Properties = map[string]*Schema{}
+	}
+
+	for _, name := range sortedKeys(property.Properties) {
+		field := property.Properties[name]
+		schema.Properties[name] = c.convertSchema(location+"."+name, &field)
+	}
+
+	if items, ok := propertyOf(property.Items); ok {
+		schema.Items = c.convertSchema(location+"[]", items)
+	}
+
+	if additional, ok := property.AdditionalProperties.(bool); ok {
+		schema.AdditionalProperties = additional
+	} else if additional, ok := propertyOf(property.AdditionalProperties); ok {
+		schema.AdditionalProperties = c.convertSchema(location+"{}", additional)
+	}
+
+	for index := range property.AllOf {
+		schema.AllOf = append(schema.AllOf, c.convertSchema(location, &property.AllOf[index]))
+	}
+
+	if len(property.OneOf) > 0 || len(property.AnyOf) > 0 {
+		c.warn(location, "`oneOf` & `anyOf` are not supported, the alternatives are dropped")
+	}
+
+	if property.Discriminator.PropertyName != "" {
+		schema.Discriminator = property.Discriminator.PropertyName
+
+		if len(property.Discriminator.Mapping) > 0 {
+			c.warn(location, "the mapping of discriminators is not supported and is dropped")
+		}
+	}
+
+	if property.WriteOnly {
+		c.warn(location, "`writeOnly` is not supported and is dropped")
+	}
+
+	if property.Deprecated {
+		c.warn(location, "deprecated schemas are not supported, the schema is described as a regular schema")
+	}
+
+	return schema
+}
+
+func (c *converter) convertReference(location string, reference string) string {
+	if reference == "" {
+		return ""
+	}
+
+	for prefix, replacement := range referencePrefixes {
+		if strings.HasPrefix(reference, prefix) {
+			return replacement + strings.TrimPrefix(reference, prefix)
+		}
+	}
+
+	c.warn(location, "the reference `%s` can't be converted", reference)
+	return reference
+}
+
+// Returns the property that is stored in an interface, e.g. the items of an array.
+func propertyOf(value interface{}) (*echo_swagger.Property, bool) {
+	switch property := value.(type) {
+	case echo_swagger.Property:
+		return &property, true
+
+	case *echo_swagger.Property:
+		return property, property != nil
+	}
+
+	return nil, false
+}
+
+// Returns the sorted keys of a map, so the conversion (and its warnings) is deterministic.
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, current := range values {
+		if current == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,340 @@
+package swagger2
+
+import (
+	"testing"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertServers(t *testing.T) {
+	assert := assert.New(t)
+
+	type testCase struct {
+		name     string
+		servers  []echo_swagger.Server
+		host     string
+		basePath string
+		schemes  []string
+		warnings []string
+	}
+
+	testCases := []testCase{
+		{
+			name: "no servers",
+		},
+		{
+			name:     "single server",
+			servers:  []echo_swagger.Server{{URL: "https://api.example.com/v1/"}},
+			host:     "api.example.com",
+			basePath: "/v1",
+			schemes:  []string{"https"},
+		},
+		{
+			name: "multiple schemes",
+			servers: []echo_swagger.Server{
+				{URL: "https://api.example.com/v1"},
+				{URL: "http://api.example.com/v1"},
+				{URL: "https://staging.example.com/v1"},
+			},
+			host:     "api.example.com",
+			basePath: "/v1",
+			schemes:  []string{"https", "http"},
+			warnings: []string{"servers: server `https://staging.example.com/v1` is dropped, only a single host & base path are supported"},
+		},
+		{
+			name: "variables",
+			servers: []echo_swagger.Server{
+				{
+					URL:       "https://{region}.example.com:{port}/api",
+					Variables: map[string]echo_swagger.ServerVariable{"region": {Default: "eu"}, "port": {Default: "8443"}},
+				},
+			},
+			host:     "eu.example.com:8443",
+			basePath: "/api",
+			schemes:  []string{"https"},
+		},
+		{
+			name:     "relative server",
+			servers:  []echo_swagger.Server{{URL: "/api"}},
+			basePath: "/api",
+		},
+	}
+
+	for _, testCase := range testCases {
+		swagger, warnings := Convert(&echo_swagger.OpenAPI{Servers: testCase.servers})
+
+		assert.Equal(testCase.host, swagger.Host, testCase.name)
+		assert.Equal(testCase.basePath, swagger.BasePath, testCase.name)
+		assert.Equal(testCase.schemes, swagger.Schemes, testCase.name)
+		assert.ElementsMatch(testCase.warnings, warnings, testCase.name)
+	}
+}
+
+func TestConvertSecurity(t *testing.T) {
+	assert := assert.New(t)
+
+	openapi := &echo_swagger.OpenAPI{
+		Paths: map[string]*echo_swagger.Path{
+			"/account": {
+				Get: &echo_swagger.Operation{
+					Security: &[]echo_swagger.SecurityRequirement{{"OpenId": {}}, {"Basic": {}}},
+					Responses: map[string]echo_swagger.Response{
+						"204": {Description: "The account"},
+					},
+				},
+			},
+			"/session": {
+				Get: &echo_swagger.Operation{
+					Security: &[]echo_swagger.SecurityRequirement{{"Session": {}}},
+					Responses: map[string]echo_swagger.Response{
+						"204": {Description: "The session"},
+					},
+				},
+			},
+			"/health": {
+				Get: &echo_swagger.Operation{
+					Security: &[]echo_swagger.SecurityRequirement{},
+					Responses: map[string]echo_swagger.Response{
+						"204": {Description: "The service is healthy"},
+					},
+				},
+			},
+		},
+		Components: echo_swagger.Components{
+			SecuritySchemes: map[string]echo_swagger.SecurityScheme{
+				"Basic":   {Type: echo_swagger.SecuritySchemeTypeHttp, Scheme: "basic"},
+				"Bearer":  {Type: echo_swagger.SecuritySchemeTypeHttp, Scheme: "bearer", BearerFormat: "JWT"},
+				"ApiKey":  {Type: echo_swagger.SecuritySchemeTypeApiKey, Name: "key", In: echo_swagger.ParameterLocationQuery},
+				"Session": {Type: echo_swagger.SecuritySchemeTypeApiKey, Name: "session", In: echo_swagger.ParameterLocationCookie},
+				"OpenId":  {Type: echo_swagger.SecuritySchemeTypeOpenIdConnect, OpenIdConnectUrl: "https://example.com/.well-known/openid-configuration"},
+				"OAuth2": {
+					Type: echo_swagger.SecuritySchemeTypeOAuth2,
+					Flows: echo_swagger.OAuthFlows{
+						ClientCredentials: &echo_swagger.OAuthFlow{TokenUrl: "https://example.com/token"},
+						AuthorizationCode: &echo_swagger.OAuthFlow{
+							AuthorizationUrl: "https://example.com/authorize",
+							TokenUrl:         "https://example.com/token",
+							Scopes:           map[string]string{"read": "Read"},
+						},
+					},
+				},
+			},
+		},
+		Security: []echo_swagger.SecurityRequirement{{"Bearer": {}}, {"Session": {}}},
+	}
+
+	swagger, warnings := Convert(openapi)
+
+	noScopes := map[string]string{}
+
+	assert.Equal(map[string]SecurityScheme{
+		"Basic":  {Type: "basic"},
+		"Bearer": {Type: "apiKey", Name: "Authorization", In: "header"},
+		"ApiKey": {Type: "apiKey", Name: "key", In: "query"},
+		"OAuth2": {Type: "oauth2", Flow: "application", TokenUrl: "https://example.com/token", Scopes: &noScopes},
+	}, swagger.SecurityDefinitions)
+
+	assert.Equal([]echo_swagger.SecurityRequirement{{"Bearer": {}}}, swagger.Security)
+	assert.Equal(&[]echo_swagger.SecurityRequirement{{"Basic": {}}}, swagger.Paths["/account"].Get.Security)
+
+	// An empty list means that no security is required, so it's kept only when it's empty in the source, while an operation
+	// whose requirements are all dropped falls back to the global requirements
+	assert.Nil(swagger.Paths["/session"].Get.Security)
+	assert.Equal(&[]echo_swagger.SecurityRequirement{}, swagger.Paths["/health"].Get.Security)
+
+	assert.Equal([]string{
+		"security scheme `Bearer`: bearer authentication is described as an API key in the `Authorization` header",
+		"security scheme `OAuth2`: the `accessCode` flow is dropped, only a single flow is supported",
+		"security scheme `OpenId`: security scheme of type `openIdConnect` is not supported, the requirements of it are dropped",
+		"security scheme `Session`: security scheme of type `apiKey in cookie` is not supported, the requirements of it are dropped",
+		"GET /session: all of the security requirements use unsupported security schemes and are dropped, the operation falls back to the global security requirements",
+	}, warnings)
+}
+
+func TestConvertOperations(t *testing.T) {
+	assert := assert.New(t)
+
+	user := echo_swagger.Property{
+		Type: echo_swagger.PropertyType_Object,
+		Properties: map[string]echo_swagger.Property{
+			"name":    {Type: echo_swagger.PropertyType_String},
+			"manager": {Reference: echo_swagger.ComponentSchemasPrefix + "api.User", Nullable: true},
+		},
+		RequiredProperties: []string{"name"},
+	}
+
+	openapi := &echo_swagger.OpenAPI{
+		Paths: map[string]*echo_swagger.Path{
+			"/users/{id}": {
+				Put: &echo_swagger.Operation{
+					Tags: []string{"Users"},
+					Parameters: []echo_swagger.Parameter{
+						{Name: "id", In: echo_swagger.ParameterLocationPath, Required: true, Schema: echo_swagger.Schema{Property: echo_swagger.Property{Type: echo_swagger.PropertyType_String}}},
+						{
+							Name: "fields",
+							In:   echo_swagger.ParameterLocationQuery,
+							Schema: echo_swagger.Schema{Property: echo_swagger.Property{
+								Type:  echo_swagger.PropertyType_Array,
+								Items: echo_swagger.Property{Type: echo_swagger.PropertyType_String},
+							}},
+						},
+						{Name: "session", In: echo_swagger.ParameterLocationCookie, Schema: echo_swagger.Schema{Property: echo_swagger.Property{Type: echo_swagger.PropertyType_String}}},
+					},
+					RequestBody: echo_swagger.RequestBody{
+						Content: map[string]echo_swagger.MediaType{
+							echo_swagger.ContentTypeJson: {Schema: echo_swagger.Schema{Property: user}},
+							echo_swagger.ContentTypeXml:  {Schema: echo_swagger.Schema{Property: user}},
+						},
+					},
+					Responses: map[string]echo_swagger.Response{
+						"200": {
+							Description: "The user",
+							Headers: map[string]echo_swagger.Header{
+								"ETag": {Schema: echo_swagger.Schema{Property: echo_swagger.Property{Type: echo_swagger.PropertyType_String}}},
+							},
+							Content: map[string]echo_swagger.MediaType{
+								echo_swagger.ContentTypeJson: {Schema: echo_swagger.Schema{Property: echo_swagger.Property{Reference: echo_swagger.ComponentSchemasPrefix + "api.User"}}},
+							},
+						},
+						"404": {Reference: echo_swagger.ComponentResponsesPrefix + "NotFound"},
+					},
+				},
+				Trace: &echo_swagger.Operation{},
+			},
+			"/users/{id}/avatar": {
+				Get: &echo_swagger.Operation{
+					Responses: map[string]echo_swagger.Response{
+						"200": {
+							Description: "The avatar",
+							Content: map[string]echo_swagger.MediaType{
+								"image/png": {Schema: echo_swagger.Schema{Property: echo_swagger.Property{Type: echo_swagger.PropertyType_String, Format: echo_swagger.PropertyFormat_Binary}}},
+							},
+						},
+					},
+				},
+				Post: &echo_swagger.Operation{
+					RequestBody: echo_swagger.RequestBody{
+						Content: map[string]echo_swagger.MediaType{
+							echo_swagger.ContentTypeMultipartForm: {
+								Schema: echo_swagger.Schema{Property: echo_swagger.Property{
+									Type: echo_swagger.PropertyType_Object,
+									Properties: map[string]echo_swagger.Property{
+										"avatar":  {Type: echo_swagger.PropertyType_String, Format: echo_swagger.PropertyFormat_Binary, Description: "The image"},
+										"caption": {Type: echo_swagger.PropertyType_String},
+										"crop":    {Type: echo_swagger.PropertyType_Object},
+									},
+									RequiredProperties: []string{"avatar"},
+								}},
+							},
+						},
+					},
+					Responses: map[string]echo_swagger.Response{
+						"204": {Description: "The avatar was uploaded"},
+					},
+				},
+			},
+		},
+		Components: echo_swagger.Components{
+			Schemas: map[string]echo_swagger.Schema{
+				"api.User": {Property: user},
+			},
+			Responses: map[string]echo_swagger.Response{
+				"NotFound": {Description: "Not found"},
+			},
+		},
+	}
+
+	swagger, warnings := Convert(openapi)
+
+	convertedUser := Schema{
+		Type: echo_swagger.PropertyType_Object,
+		Properties: map[string]*Schema{
+			"name":    {Type: echo_swagger.PropertyType_String},
+			"manager": {Reference: DefinitionsPrefix + "api.User", Nullable: true},
+		},
+		Required: []string{"name"},
+	}
+
+	assert.Equal(map[string]*Path{
+		"/users/{id}": {
+			Parameters: []Parameter{},
+			Put: &Operation{
+				Tags:     []string{"Users"},
+				Consumes: []string{echo_swagger.ContentTypeJson, echo_swagger.ContentTypeXml},
+				Produces: []string{echo_swagger.ContentTypeJson},
+				Parameters: []Parameter{
+					{Name: "id", In: "path", Required: true, Items: Items{Type: "string"}},
+					{Name: "fields", In: "query", Items: Items{Type: "array", Items: &Items{Type: "string"}, CollectionFormat: "multi"}},
+					{Name: BodyParameterName, In: "body", Schema: &convertedUser},
+				},
+				Responses: map[string]Response{
+					"200": {
+						Description: "The user",
+						Headers:     map[string]Header{"ETag": {Items: Items{Type: "string"}}},
+						Schema:      &Schema{Reference: DefinitionsPrefix + "api.User"},
+					},
+					"404": {Reference: ResponsesPrefix + "NotFound"},
+				},
+			},
+		},
+		"/users/{id}/avatar": {
+			Parameters: []Parameter{},
+			Get: &Operation{
+				Parameters: []Parameter{},
+				Produces:   []string{"image/png"},
+				Responses: map[string]Response{
+					"200": {Description: "The avatar", Schema: &Schema{Type: "file"}},
+				},
+			},
+			Post: &Operation{
+				Consumes: []string{echo_swagger.ContentTypeMultipartForm},
+				Parameters: []Parameter{
+					{Name: "avatar", In: "formData", Description: "The image", Required: true, Items: Items{Type: "file"}},
+					{Name: "caption", In: "formData", Items: Items{Type: "string"}},
+				},
+				Responses: map[string]Response{
+					"204": {Description: "The avatar was uploaded"},
+				},
+			},
+		},
+	}, swagger.Paths)
+
+	assert.Equal(map[string]Schema{"api.User": convertedUser}, swagger.Definitions)
+	assert.Equal(map[string]Response{"NotFound": {Description: "Not found"}}, swagger.Responses)
+
+	assert.Equal([]string{
+		"PUT /users/{id} cookie parameter `session`: cookie parameters are not supported, the parameter is dropped",
+		"TRACE /users/{id}: the method `TRACE` is not supported, the operation is dropped",
+		"POST /users/{id}/avatar form field `crop`: only primitives and arrays can be described outside of a body, the value is dropped",
+	}, warnings)
+}
+
+func TestMarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	swagger, _ := Convert(&echo_swagger.OpenAPI{
+		Info: echo_swagger.Info{Title: "Example", Version: "1.0"},
+		Paths: map[string]*echo_swagger.Path{
+			"/health": {
+				Get: &echo_swagger.Operation{
+					Responses: map[string]echo_swagger.Response{"204": {Description: "Healthy"}},
+				},
+			},
+		},
+	})
+
+	data, err := swagger.Marshal(echo_swagger.FormatYaml)
+	assert.NoError(err)
+	assert.Equal(`swagger: "2.0"
+info:
+    title: Example
+    version: "1.0"
+paths:
+    /health:
+        get:
+            responses:
+                "204":
+                    description: Healthy
+`, string(data))
+}
@@ -0,0 +1,289 @@
+package swagger2
+
+import (
+	"strings"
+
+	"github.com/avivatedgi/echo-swagger/echo_swagger"
+	"gopkg.in/yaml.v3"
+)
+
+const SwaggerVersion = "2.0"
+
+// This is the root document object of the Swagger 2.0 specifications.
+type Swagger struct {
+	// REQUIRED. Specifies the Swagger Specification version being used.
+	Swagger string `yaml:"swagger" json:"swagger"`
+
+	// REQUIRED. Provides metadata about the API.
+	Info echo_swagger.Info `yaml:"info,omitempty" json:"info,omitempty"`
+
+	// The host (name or ip) serving the API, including the port.
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+
+	// The base path on which the API is served, which is relative to the host. The value MUST start with a leading slash (/).
+	BasePath string `yaml:"basePath,omitempty" json:"basePath,omitempty"`
+
+	// The transfer protocol of the API. Values MUST be from the list: "http", "https", "ws", "wss".
+	Schemes []string `yaml:"schemes,omitempty" json:"schemes,omitempty"`
+
+	// REQUIRED. The available paths and operations for the API.
+	Paths map[string]*Path `yaml:"paths" json:"paths"`
+
+	// An object to hold data types produced and consumed by operations.
+	Definitions map[string]Schema `yaml:"definitions,omitempty" json:"definitions,omitempty"`
+
+	// An object to hold responses that can be used across operations.
+	Responses map[string]Response `yaml:"responses,omitempty" json:"responses,omitempty"`
+
+	// Security scheme definitions that can be used across the specification.
+	SecurityDefinitions map[string]SecurityScheme `yaml:"securityDefinitions,omitempty" json:"securityDefinitions,omitempty"`
+
+	// A declaration of which security schemes are applied for the API as a whole.
+	Security []echo_swagger.SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+
+	// A list of tags used by the specification with additional metadata.
+	Tags []echo_swagger.Tag `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// Additional external documentation.
+	ExternalDocs echo_swagger.ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+}
+
+// Marshal the Swagger specifications in the given format.
+func (swagger Swagger) Marshal(format echo_swagger.Format) ([]byte, error) {
+	return echo_swagger.MarshalDocument(swagger, format)
+}
+
+// Describes the operations available on a single path.
+type Path struct {
+	Get     *Operation `yaml:"get,omitempty" json:"get,omitempty"`
+	Put     *Operation `yaml:"put,omitempty" json:"put,omitempty"`
+	Post    *Operation `yaml:"post,omitempty" json:"post,omitempty"`
+	Delete  *Operation `yaml:"delete,omitempty" json:"delete,omitempty"`
+	Options *Operation `yaml:"options,omitempty" json:"options,omitempty"`
+	Head    *Operation `yaml:"head,omitempty" json:"head,omitempty"`
+	Patch   *Operation `yaml:"patch,omitempty" json:"patch,omitempty"`
+
+	// A list of parameters that are applicable for all the operations described under this path.
+	Parameters []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+}
+
+// Set the operation of the path by its method, returns false for the methods that Swagger 2.0 doesn't support.
+func (p *Path) SetOperationByMethod(method string, operation *Operation) bool {
+	switch strings.ToUpper(method) {
+	case "GET":
+		p.Get = operation
+
+	case "PUT":
+		p.Put = operation
+
+	case "POST":
+		p.Post = operation
+
+	case "DELETE":
+		p.Delete = operation
+
+	case "OPTIONS":
+		p.Options = operation
+
+	case "HEAD":
+		p.Head = operation
+
+	case "PATCH":
+		p.Patch = operation
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Describes a single API operation on a path.
+type Operation struct {
+	// A list of tags for API documentation control.
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// A short summary of what the operation does.
+	Summary string `yaml:"summary,omitempty" json:"summary,omitempty"`
+
+	// A verbose explanation of the operation behavior.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Additional external documentation for this operation.
+	ExternalDocs echo_swagger.ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+
+	// Unique string used to identify the operation.
+	OperationId string `yaml:"operationId,omitempty" json:"operationId,omitempty"`
+
+	// A list of MIME types the operation can consume.
+	Consumes []string `yaml:"consumes,omitempty" json:"consumes,omitempty"`
+
+	// A list of MIME types the operation can produce.
+	Produces []string `yaml:"produces,omitempty" json:"produces,omitempty"`
+
+	// A list of parameters that are applicable for this operation.
+	Parameters []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+
+	// REQUIRED. The list of possible responses as they are returned from executing this operation.
+	Responses map[string]Response `yaml:"responses" json:"responses"`
+
+	// Declares this operation to be deprecated.
+	Deprecated bool `yaml:"deprecated,omitempty" json:"deprecated,omitempty"`
+
+	// A declaration of which security schemes are applied for this operation, overriding the top-level security.
+	Security *[]echo_swagger.SecurityRequirement `yaml:"security,omitempty" json:"security,omitempty"`
+}
+
+// The primitive type of a non-body parameter, a header or the items of an array of them.
+type Items struct {
+	// REQUIRED. The type of the value: "string", "number", "integer", "boolean", "array" or "file" (for form parameters).
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// The extending format for the type.
+	Format echo_swagger.PropertyFormat `yaml:"format,omitempty" json:"format,omitempty"`
+
+	// Required if type is "array". Describes the type of items in the array.
+	Items *Items `yaml:"items,omitempty" json:"items,omitempty"`
+
+	// Determines the format of the array if type array is used: "csv", "ssv", "tsv", "pipes" or "multi".
+	CollectionFormat string `yaml:"collectionFormat,omitempty" json:"collectionFormat,omitempty"`
+
+	Default          interface{}   `yaml:"default,omitempty" json:"default,omitempty"`
+	Enum             []interface{} `yaml:"enum,omitempty" json:"enum,omitempty"`
+	Minimum          *float64      `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	ExclusiveMinimum bool          `yaml:"exclusiveMinimum,omitempty" json:"exclusiveMinimum,omitempty"`
+	Maximum          *float64      `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	ExclusiveMaximum bool          `yaml:"exclusiveMaximum,omitempty" json:"exclusiveMaximum,omitempty"`
+	MinLength        *uint64       `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength        *uint64       `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	MinItems         *uint64       `yaml:"minItems,omitempty" json:"minItems,omitempty"`
+	MaxItems         *uint64       `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
+	UniqueItems      bool          `yaml:"uniqueItems,omitempty" json:"uniqueItems,omitempty"`
+}
+
+// Describes a single operation parameter, either a body parameter (described by its schema) or a primitive parameter.
+type Parameter struct {
+	// REQUIRED. The name of the parameter.
+	Name string `yaml:"name" json:"name"`
+
+	// REQUIRED. The location of the parameter: "query", "header", "path", "formData" or "body".
+	In string `yaml:"in" json:"in"`
+
+	// A brief description of the parameter.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Determines whether this parameter is mandatory.
+	Required bool `yaml:"required,omitempty" json:"required,omitempty"`
+
+	// REQUIRED for body parameters. The schema defining the type used for the body parameter.
+	Schema *Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
+
+	// Sets the ability to pass empty-valued parameters, valid only for query or formData parameters.
+	AllowEmptyValue bool `yaml:"allowEmptyValue,omitempty" json:"allowEmptyValue,omitempty"`
+
+	// The type of the non-body parameters.
+	Items `yaml:",inline"`
+}
+
+// Describes a single response from an API Operation.
+type Response struct {
+	// A reference to a response that is defined in the responses of the specifications.
+	Reference string `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+
+	// REQUIRED. A short description of the response.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// A definition of the response structure.
+	Schema *Schema `yaml:"schema,omitempty" json:"schema,omitempty"`
+
+	// A list of headers that are sent with the response.
+	Headers map[string]Header `yaml:"headers,omitempty" json:"headers,omitempty"`
+}
+
+// Describes a header that is sent as part of a response.
+type Header struct {
+	// A short description of the header.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// The type of the header.
+	Items `yaml:",inline"`
+}
+
+// The Schema Object allows the definition of input and output data types, a subset of the JSON Schema Specification Draft 4.
+type Schema struct {
+	Reference            string                             `yaml:"$ref,omitempty" json:"$ref,omitempty"`
+	Type                 echo_swagger.PropertyType          `yaml:"type,omitempty" json:"type,omitempty"`
+	Format               echo_swagger.PropertyFormat        `yaml:"format,omitempty" json:"format,omitempty"`
+	Description          string                             `yaml:"description,omitempty" json:"description,omitempty"`
+	Properties           map[string]*Schema                 `yaml:"properties,omitempty" json:"properties,omitempty"`
+	PropertiesOrder      []string                           `yaml:"-" json:"-"`
+	Required             []string                           `yaml:"required,omitempty" json:"required,omitempty"`
+	Items                *Schema                            `yaml:"items,omitempty" json:"items,omitempty"`
+	AdditionalProperties interface{}                        `yaml:"additionalProperties,omitempty" json:"additionalProperties,omitempty"`
+	AllOf                []*Schema                          `yaml:"allOf,omitempty" json:"allOf,omitempty"`
+	Discriminator        string                             `yaml:"discriminator,omitempty" json:"discriminator,omitempty"`
+	ReadOnly             bool                               `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+	XML                  *echo_swagger.XML                  `yaml:"xml,omitempty" json:"xml,omitempty"`
+	ExternalDocs         echo_swagger.ExternalDocumentation `yaml:"externalDocs,omitempty" json:"externalDocs,omitempty"`
+	Example              interface{}                        `yaml:"example,omitempty" json:"example,omitempty"`
+	Default              interface{}                        `yaml:"default,omitempty" json:"default,omitempty"`
+	Enum                 []interface{}                      `yaml:"enum,omitempty" json:"enum,omitempty"`
+	Minimum              *float64                           `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+	ExclusiveMinimum     bool                               `yaml:"exclusiveMinimum,omitempty" json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64                           `yaml:"maximum,omitempty" json:"maximum,omitempty"`
+	ExclusiveMaximum     bool                               `yaml:"exclusiveMaximum,omitempty" json:"exclusiveMaximum,omitempty"`
+	MinLength            *uint64                            `yaml:"minLength,omitempty" json:"minLength,omitempty"`
+	MaxLength            *uint64                            `yaml:"maxLength,omitempty" json:"maxLength,omitempty"`
+	MinItems             *uint64                            `yaml:"minItems,omitempty" json:"minItems,omitempty"`
+	MaxItems             *uint64                            `yaml:"maxItems,omitempty" json:"maxItems,omitempty"`
+	UniqueItems          bool                               `yaml:"uniqueItems,omitempty" json:"uniqueItems,omitempty"`
+	MinProperties        *uint64                            `yaml:"minProperties,omitempty" json:"minProperties,omitempty"`
+	MaxProperties        *uint64                            `yaml:"maxProperties,omitempty" json:"maxProperties,omitempty"`
+
+	// Swagger 2.0 has no nullable schemas, so the common vendor extension is used instead
+	Nullable bool `yaml:"x-nullable,omitempty" json:"x-nullable,omitempty"`
+
+	EnumDescriptions []string `yaml:"x-enum-descriptions,omitempty" json:"x-enum-descriptions,omitempty"`
+}
+
+// Marshal the schema with its properties in the order of their declaration, see echo_swagger.Property.MarshalYAML.
+func (s Schema) MarshalYAML() (interface{}, error) {
+	// The alias doesn't have the MarshalYAML method, so it is encoded by its fields
+	type schema Schema
+
+	node := &yaml.Node{}
+	if err := node.Encode(schema(s)); err != nil {
+		return nil, err
+	}
+
+	echo_swagger.OrderProperties(node, s.PropertiesOrder)
+	return node, nil
+}
+
+// Defines a security scheme that can be used by the operations.
+type SecurityScheme struct {
+	// REQUIRED. The type of the security scheme: "basic", "apiKey" or "oauth2".
+	Type string `yaml:"type" json:"type"`
+
+	// A short description for security scheme.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// REQUIRED for apiKey. The name of the header or query parameter to be used.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// REQUIRED for apiKey. The location of the API key: "query" or "header".
+	In string `yaml:"in,omitempty" json:"in,omitempty"`
+
+	// REQUIRED for oauth2. The flow used by the OAuth2 security scheme: "implicit", "password", "application" or "accessCode".
+	Flow string `yaml:"flow,omitempty" json:"flow,omitempty"`
+
+	// REQUIRED for the implicit and accessCode flows. The authorization URL to be used for this flow.
+	AuthorizationUrl string `yaml:"authorizationUrl,omitempty" json:"authorizationUrl,omitempty"`
+
+	// REQUIRED for the password, application and accessCode flows. The token URL to be used for this flow.
+	TokenUrl string `yaml:"tokenUrl,omitempty" json:"tokenUrl,omitempty"`
+
+	// REQUIRED for oauth2. The available scopes for the OAuth2 security scheme, a pointer so the oauth2 schemes
+	// without scopes still declare them.
+	Scopes *map[string]string `yaml:"scopes,omitempty" json:"scopes,omitempty"`
+}
@@ -0,0 +1,88 @@
+package discovery
+
+import "github.com/labstack/echo/v4"
+
+const MethodDelete = "DELETE"
+
+func Handle[T any](c echo.Context) error { return nil }
+
+type Handler interface {
+	Handle(c echo.Context) error
+}
+
+func Wrap(handler Handler) echo.HandlerFunc { return handler.Handle }
+
+type Server struct {
+	admin *echo.Group
+}
+
+type GetUserRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	// @response 200
+	// @description The user
+	OKResponse struct {
+		Username string `json:"username"`
+	}
+}
+
+// @summary Update a user
+type UpdateUserRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	Body struct {
+		Username string `json:"username"`
+	}
+
+	// @response 204
+	// @description The user was updated
+	NoContentResponse struct{}
+}
+
+func (r *UpdateUserRequest) Handle(c echo.Context) error { return nil }
+
+// The explicit attributes win over the registration
+// @route /v2/users/{id}
+type DeleteUserRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	// @response 204
+	// @description The user was deleted
+	NoContentResponse struct{}
+}
+
+type ListAuditRequest struct {
+	// @response 200
+	// @description The audit log
+	OKResponse []string
+}
+
+type HealthRequest struct {
+	// @response 204
+	// @description The service is healthy
+	NoContentResponse struct{}
+}
+
+func Register(e *echo.Echo, server *Server) {
+	api := e.Group("/api")
+	users := api.Group("/users")
+
+	users.GET("/:id", Handle[GetUserRequest])
+	users.PATCH("/:id", Wrap(&UpdateUserRequest{}))
+	e.Add(MethodDelete, "/users/:id", Handle[DeleteUserRequest])
+
+	server.admin = api.Group("/admin")
+	server.admin.GET("/audit", Handle[ListAuditRequest])
+
+	e.Group("/internal").GET("/health", (&HealthRequest{}).Handle)
+
+	registerProfiles(api.Group("/profiles"))
+}
+
+func (r *HealthRequest) Handle(c echo.Context) error { return nil }
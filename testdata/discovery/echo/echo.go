@@ -0,0 +1,24 @@
+// A stub of the routing API of echo, so the routes discovery can be tested without the real module.
+package echo
+
+type Context interface{}
+
+type HandlerFunc func(c Context) error
+
+type Echo struct{}
+
+func New() *Echo { return &Echo{} }
+
+func (e *Echo) GET(path string, h HandlerFunc)         {}
+func (e *Echo) POST(path string, h HandlerFunc)        {}
+func (e *Echo) PUT(path string, h HandlerFunc)         {}
+func (e *Echo) DELETE(path string, h HandlerFunc)      {}
+func (e *Echo) Add(method, path string, h HandlerFunc) {}
+func (e *Echo) Group(prefix string) *Group             { return &Group{} }
+
+type Group struct{}
+
+func (g *Group) GET(path string, h HandlerFunc)   {}
+func (g *Group) POST(path string, h HandlerFunc)  {}
+func (g *Group) PATCH(path string, h HandlerFunc) {}
+func (g *Group) Group(prefix string) *Group       { return &Group{} }
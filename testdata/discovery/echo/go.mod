@@ -0,0 +1,3 @@
+module github.com/labstack/echo/v4
+
+go 1.18
@@ -0,0 +1,20 @@
+package discovery
+
+import "github.com/labstack/echo/v4"
+
+type GetProfileRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	// @response 200
+	// @description The profile
+	OKResponse struct {
+		Bio string `json:"bio"`
+	}
+}
+
+// The prefix of the group parameter is unknown, so its routes can't be discovered
+func registerProfiles(g *echo.Group) {
+	g.GET("/:id", Handle[GetProfileRequest])
+}
@@ -17,3 +17,28 @@ type MissingRouteAttribute struct{}
 // @route /missing/method
 // @description Missing @method attribute
 type MissingMethodAttribute struct{}
+
+// @route /missing/{id}/variables/{name}
+// @method GET
+type MissingPathParameterRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	// @response 200
+	// @description A response
+	OKResponse struct{}
+}
+
+// @route /extra/:id
+// @method GET
+type ExtraPathParameterRequest struct {
+	Path struct {
+		Id   string `binder:"id"`
+		Name string `binder:"name"`
+	}
+
+	// @response 200
+	// @description A response
+	OKResponse struct{}
+}
@@ -0,0 +1,7 @@
+module example.com/handlers
+
+go 1.18
+
+require github.com/labstack/echo/v4 v4.0.0
+
+replace github.com/labstack/echo/v4 => ../discovery/echo
@@ -0,0 +1,39 @@
+package handlers
+
+import "github.com/labstack/echo/v4"
+
+// An interface of handlers that aren't echo handlers
+type Job interface {
+	Run() error
+}
+
+// Detected by the `RequestHandler` interface, although its name doesn't end with `Request`
+// @route /profile
+// @method GET
+type Profile struct {
+	// @response 204
+	// @description The profile
+	NoContentResponse struct{}
+}
+
+func (p *Profile) Handle(c echo.Context) error { return nil }
+
+// Detected only by its name, as it doesn't implement any interface
+// @route /legacy
+// @method GET
+type LegacyRequest struct {
+	// @response 204
+	// @description The legacy route
+	NoContentResponse struct{}
+}
+
+// Detected only by the `Job` interface
+// @route /cleanup
+// @method POST
+type Cleanup struct {
+	// @response 202
+	// @description The cleanup was started
+	AcceptedResponse struct{}
+}
+
+func (c Cleanup) Run() error { return nil }
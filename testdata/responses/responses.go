@@ -0,0 +1,5 @@
+package responses
+
+// @route /ping
+// @method GET
+type PingRequest struct{}
@@ -0,0 +1,99 @@
+package shared
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+// @sharedResponse 401 Unauthorized
+// @description The request is not authenticated
+type UnauthorizedResponse struct {
+	Error
+}
+
+// @sharedResponse 403
+// @description The user is not allowed to access the resource
+// @tags Admin
+type ForbiddenResponse struct {
+	Error
+	Permission string `json:"permission"`
+}
+
+// @sharedResponse 5XX ServerError
+// @description An unexpected error
+type ServerErrorResponse struct {
+	Error
+}
+
+// The name of the component is already taken by UnauthorizedResponse
+// @sharedResponse 401 Unauthorized
+// @description The token of the request is invalid
+type InvalidTokenResponse struct {
+	Error
+}
+
+type (
+	// @sharedResponse 429 TooManyRequests
+	// @description Too many requests
+	TooManyRequestsResponse struct {
+		Error
+	}
+
+	RateLimit struct {
+		Remaining int `json:"remaining"`
+	}
+
+	// @route /limits
+	// @method GET
+	GetLimitsRequest struct {
+		// @response 200
+		// @description The rate limit
+		OKResponse RateLimit
+	}
+
+	ResetLimitsRequest struct {
+		// @response 204
+		// @description The rate limit was reset
+		NoContentResponse struct{}
+	}
+)
+
+// @route /me
+// @method GET
+type GetMeRequest struct {
+	// @response 200
+	// @description The current user
+	OKResponse struct {
+		Id string `json:"id"`
+	}
+
+	// @response 401
+	// @description The session of the user has expired
+	UnauthorizedResponse struct {
+		Reason string `json:"reason"`
+	}
+}
+
+// @route /admin/users/{id}
+// @method DELETE
+// @tags Admin
+type DeleteUserRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	// @response default
+	// @description An error
+	DefaultResponse struct {
+		Error
+	}
+}
+
+// @route /invalid
+// @method GET
+type InvalidStatusCodeRequest struct {
+	// @response 600
+	// @description An invalid status code
+	InvalidResponse struct {
+		Error
+	}
+}
@@ -0,0 +1,39 @@
+package valid
+
+type Audit struct {
+	CreatedBy string `json:"createdBy"`
+	Version   int    `json:"version"`
+}
+
+type Base struct {
+	Id string `json:"id"`
+}
+
+type Left struct {
+	Label string `json:"label"`
+}
+
+type Right struct {
+	Label string `json:"label"`
+}
+
+// @route /documents
+// @method PUT
+type UpdateDocumentRequest struct {
+	Body struct {
+		*Audit
+		Base `json:"base"`
+		Left
+		Right
+
+		Version string `json:"version"`
+		Count   int64  `json:"count,string"`
+		Enabled bool   `json:"enabled,string,omitempty"`
+		Name    string `json:",omitempty"`
+		secret  string
+	}
+
+	// @response 204
+	// @description The document was updated
+	NoContentResponse struct{}
+}
@@ -0,0 +1,28 @@
+package valid
+
+// The status of an order
+type Status string
+
+const (
+	// The order is waiting for a payment
+	StatusPending Status = "pending"
+
+	// The order was paid
+	StatusPaid Status = "paid"
+
+	StatusCancelled Status = "cancelled"
+)
+
+// @route /orders
+// @method GET
+type ListOrdersRequest struct {
+	Query struct {
+		Status Status `binder:"status"`
+	}
+
+	// @response 200
+	// @description The orders
+	OKResponse struct {
+		Status Status `json:"status" validate:"required,oneof=paid"`
+	}
+}
@@ -0,0 +1,50 @@
+package valid
+
+// A stream of the rows of a report
+type RowStream interface {
+	Read(p []byte) (n int, err error)
+}
+
+type ReportRow struct {
+	XMLName struct{} `json:"-" xml:"row"`
+	Id      string   `json:"id" xml:"id,attr"`
+	Total   int      `json:"total" xml:"total"`
+}
+
+// @route /reports/{id}
+// @method GET
+// @produce application/json application/xml
+type GetReportRequest struct {
+	Path struct {
+		CommonPath
+	}
+
+	// @response 200
+	// @description The report
+	OKResponse struct {
+		Rows []ReportRow `json:"rows" xml:"row"`
+	}
+
+	// @response 404
+	// @description The report was not found
+	// @contentType text/plain
+	NotFoundResponse string
+}
+
+// @route /reports/:id/export
+// @method GET
+// @produce text/csv application/pdf
+type ExportReportRequest struct {
+	Path struct {
+		CommonPath
+	}
+
+	// @response 200
+	// @description The exported report
+	OKResponse RowStream
+
+	// @response 206
+	// @description A part of the exported report
+	// @contentType text/csv
+	PartialContentResponse []byte
+}
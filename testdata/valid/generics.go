@@ -0,0 +1,32 @@
+package valid
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type Envelope[T any] struct {
+	Data  T      `json:"data"`
+	Error string `json:"error"`
+}
+
+type Identifier interface {
+	~string
+}
+
+type Lookup[K Identifier, V any] struct {
+	Key    K            `json:"key"`
+	Values map[string]V `json:"values"`
+}
+
+// @route /users
+// @method GET
+type ListUsersRequest struct {
+	// @response 200
+	// @description A page of users
+	OKResponse struct {
+		Users  Page[User]            `json:"users"`
+		Nested Envelope[Page[User]]  `json:"nested"`
+		Lookup Lookup[string, *User] `json:"lookup"`
+	}
+}
@@ -0,0 +1,43 @@
+package valid
+
+type RateLimitHeader struct {
+	// The amount of requests that are left in the current window
+	RateLimit int `binder:"X-RateLimit-Remaining"`
+}
+
+// @route /users
+// @method POST
+type CreateUserRequest struct {
+	Body struct {
+		Username string `json:"username"`
+	}
+
+	// @response 201
+	// @description The user was created
+	CreatedResponse struct {
+		Header struct {
+			RateLimitHeader
+
+			// The URL of the created user
+			Location string `binder:"Location" validate:"required"`
+			ETag     string `binder:"ETag"`
+		}
+
+		Id string `json:"id"`
+	}
+
+	// @response 400
+	// @description The request has an invalid header
+	BadRequestResponse struct {
+		// The name of the invalid header
+		Header string `json:"header"`
+	}
+
+	// @response 429
+	// @description Too many requests
+	TooManyRequestsResponse struct {
+		Header struct {
+			RetryAfter int `binder:"Retry-After"`
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package valid
+
+// A date in the format of YYYY-MM-DD
+// @schema type=string format=date
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"0000-00-00"`), nil
+}
+
+type Color struct {
+	Red   uint8
+	Green uint8
+	Blue  uint8
+}
+
+func (c *Color) MarshalText() ([]byte, error) {
+	return []byte{'0', '0', '0', '0', '0', '0'}, nil
+}
+
+// A type with a custom encoding which is not documented, so it can't be described
+type Opaque struct {
+	Data []int
+}
+
+func (o Opaque) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// A level that is encoded by its name in JSON, but bound from its number in query parameters
+type Level int
+
+func (l Level) MarshalJSON() ([]byte, error) {
+	return []byte(`"level"`), nil
+}
+
+// @route /calendar
+// @method GET
+type GetCalendarRequest struct {
+	Query struct {
+		Level Level `binder:"level"`
+		Color Color `binder:"color"`
+	}
+
+	// @response 200
+	// @description The calendar
+	OKResponse struct {
+		Date   Date   `json:"date"`
+		Color  Color  `json:"color"`
+		Opaque Opaque `json:"opaque"`
+	}
+}
@@ -0,0 +1,42 @@
+package valid
+
+// An event that happened in the system
+// @oneOf UserCreated UserDeleted
+// @discriminator type
+type Event interface {
+	EventType() string
+}
+
+// @discriminatorValue user.created
+type UserCreated struct {
+	Type string `json:"type"`
+	User User   `json:"user"`
+}
+
+func (UserCreated) EventType() string { return "user.created" }
+
+type UserDeleted struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+func (UserDeleted) EventType() string { return "user.deleted" }
+
+// @route /events
+// @method POST
+type PublishEventsRequest struct {
+	Body struct {
+		Events []Event `json:"events"`
+
+		// The actor of the events
+		// @anyOf User Employee
+		// @discriminator kind user=User employee=Employee
+		Actor interface{} `json:"actor"`
+
+		Metadata interface{} `json:"metadata"`
+	}
+
+	// @response 202
+	// @description The events were accepted
+	AcceptedResponse struct{}
+}
@@ -0,0 +1,35 @@
+package valid
+
+type Category struct {
+	Name     string      `json:"name"`
+	Parent   *Category   `json:"parent"`
+	Children []*Category `json:"children"`
+}
+
+type Employee struct {
+	Name       string      `json:"name"`
+	Department *Department `json:"department"`
+}
+
+type Department struct {
+	Name      string     `json:"name"`
+	Employees []Employee `json:"employees"`
+}
+
+type Tree map[string]Tree
+
+// @route /categories/{id}
+// @method GET
+type GetCategoryRequest struct {
+	Path struct {
+		Id string `binder:"id"`
+	}
+
+	// @response 200
+	// @description The category with its sub categories
+	OKResponse struct {
+		Category   Category   `json:"category"`
+		Department Department `json:"department"`
+		Tree       Tree       `json:"tree"`
+	}
+}
@@ -0,0 +1,19 @@
+package valid
+
+// @route /account
+// @method GET
+// @security OAuth2 read:users | ApiKey
+type GetAccountRequest struct {
+	// @response 200
+	// @description The account
+	OKResponse User
+}
+
+// @route /health
+// @method GET
+// @security none
+type HealthRequest struct {
+	// @response 204
+	// @description The service is healthy
+	NoContentResponse struct{}
+}
@@ -0,0 +1,26 @@
+package valid
+
+import "mime/multipart"
+
+// @route /users/{id}/avatar
+// @method PUT
+// @accept multipart/form-data application/x-www-form-urlencoded
+type UploadAvatarRequest struct {
+	Path struct {
+		CommonPath
+	}
+
+	Body struct {
+		// The image of the avatar
+		// @contentType image/png, image/jpeg
+		Avatar *multipart.FileHeader `form:"avatar" validate:"required"`
+
+		Attachments []*multipart.FileHeader `form:"attachments"`
+		Caption     string                  `form:"caption"`
+		Unused      string                  `form:"-"`
+	}
+
+	// @response 204
+	// @description The avatar was uploaded
+	NoContentResponse struct{}
+}
@@ -13,14 +13,24 @@ type CommonPath struct {
 }
 
 type CommonQuery struct {
+	// The page number to fetch
+	// @default 1
 	Page   int `binder:"page"`
 	Amount int `binder:"amount"`
 }
 
 type User struct {
-	Id       string `json:"id"`
+	// The unique identifier of the user
+	// @format uuid
+	// @readOnly
+	Id string `json:"id"`
+
+	// @example avivatedgi
 	Username string `json:"username"`
-	Age      int    `json:"age"`
+
+	// The age of the user in years
+	// @example 21
+	Age int `json:"age"`
 }
 
 type Users []User
@@ -52,10 +62,20 @@ type ExampleRequest struct {
 
 	Header struct {
 		CommonHeader
+		// The version of the API
+		// @enum v1 v2
+		// @deprecated
 		Version string `binder:"Version"`
 		Unused  string `binder:"-"`
 	}
 
+	Cookie struct {
+		// The session of the user
+		Session string `binder:"session" validate:"required"`
+		Theme   string `binder:"theme"`
+		Unused  string `binder:"-"`
+	}
+
 	// @response 200
 	// @description A valid response
 	OKResponse struct {
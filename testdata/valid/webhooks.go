@@ -0,0 +1,14 @@
+package valid
+
+// @description The notification that is sent whenever a user is created
+// @webhook userCreated
+// @method POST
+type UserCreatedRequest struct {
+	Body struct {
+		Username string `json:"username"`
+	}
+
+	// @response 200
+	// @description The notification was received
+	OKResponse struct{}
+}
@@ -0,0 +1,12 @@
+module example.com/wellknown
+
+go 1.18
+
+require (
+	github.com/google/uuid v1.3.0
+	github.com/shopspring/decimal v1.3.1
+)
+
+replace github.com/google/uuid => ./uuid
+
+replace github.com/shopspring/decimal => ./decimal
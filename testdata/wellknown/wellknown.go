@@ -0,0 +1,48 @@
+package wellknown
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"mime/multipart"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// @route /invoices
+// @method POST
+// @accept multipart/form-data
+type CreateInvoiceRequest struct {
+	Query struct {
+		DueAt   time.Time     `binder:"dueAt"`
+		Timeout time.Duration `binder:"timeout"`
+	}
+
+	Body struct {
+		Receipt     *multipart.FileHeader   `form:"receipt"`
+		Attachments []*multipart.FileHeader `form:"attachments"`
+	}
+
+	// @response 201
+	// @description The invoice
+	CreatedResponse struct {
+		Id        uuid.UUID        `json:"id"`
+		Amount    decimal.Decimal  `json:"amount"`
+		Total     *big.Int         `json:"total"`
+		IssuedAt  time.Time        `json:"issuedAt"`
+		PaidAt    *time.Time       `json:"paidAt"`
+		Interval  time.Duration    `json:"interval"`
+		Link      url.URL          `json:"link"`
+		Metadata  json.RawMessage  `json:"metadata"`
+		Note      sql.NullString   `json:"note"`
+		Reference sql.NullInt64    `json:"reference"`
+		Parent    *uuid.UUID       `json:"parent"`
+		Related   []uuid.UUID      `json:"related"`
+		Payment   sql.NullTime     `json:"payment"`
+		Rate      *big.Float       `json:"rate"`
+		Discount  *decimal.Decimal `json:"discount"`
+	}
+}